@@ -35,5 +35,5 @@ func (botLog *BotLog) HasFailedStatus() bool {
 
 // HasCanceledStatus returns true if aggregated status is canceled
 func (botLog *BotLog) HasCanceledStatus() bool {
-	return HasSucceededStatus(botLog.Steps)
+	return HasCanceledStatus(botLog.Steps)
 }
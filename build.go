@@ -30,6 +30,7 @@ type Build struct {
 	Duration             time.Duration              `json:"duration"`
 	PendingDuration      *time.Duration             `json:"pendingDuration,omitempty"`
 	ManifestObject       *manifest.ZiplineeManifest `json:"-"`
+	ManifestWarnings     []Warning                  `json:"-"`
 	Groups               []*Group                   `json:"groups,omitempty"`
 	Organizations        []*Organization            `json:"organizations,omitempty"`
 }
@@ -138,12 +138,35 @@ func HasFailedStatus(steps []*BuildLogStep) bool {
 
 // HasCanceledStatus returns true if aggregated status is canceled
 func (buildLog *BuildLog) HasCanceledStatus() bool {
-	return HasSucceededStatus(buildLog.Steps)
+	return HasCanceledStatus(buildLog.Steps)
 }
 
 // HasCanceledStatus returns true if aggregated status is canceled
 func HasCanceledStatus(steps []*BuildLogStep) bool {
 	status := GetAggregatedStatus(steps)
 
-	return status == LogStatusSucceeded
+	return status == LogStatusCanceled
+}
+
+// walkBuildLogSteps calls fn for every step, nested step and service in depth-first order, passing the names leading up to and including the step
+func walkBuildLogSteps(steps []*BuildLogStep, fn func(path []string, step *BuildLogStep, logType LogType)) {
+	walkBuildLogStepsWithParent(nil, steps, LogTypeStage, fn)
+}
+
+func walkBuildLogStepsWithParent(parentPath []string, steps []*BuildLogStep, logType LogType, fn func(path []string, step *BuildLogStep, logType LogType)) {
+	for _, s := range steps {
+		if s == nil {
+			continue
+		}
+
+		// copy to avoid sharing the backing array between siblings
+		path := make([]string, len(parentPath), len(parentPath)+1)
+		copy(path, parentPath)
+		path = append(path, s.Step)
+
+		fn(path, s, logType)
+
+		walkBuildLogStepsWithParent(path, s.NestedSteps, LogTypeStage, fn)
+		walkBuildLogStepsWithParent(path, s.Services, LogTypeService, fn)
+	}
 }
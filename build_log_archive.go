@@ -0,0 +1,392 @@
+package contracts
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// logArchiveMagic starts and ends every log archive; the trailing copy follows the offset and length of the index
+var logArchiveMagic = []byte("ZCILOGA1")
+
+const (
+	logArchiveFooterLength = 16 + 8
+
+	// DefaultLogArchiveChunkLines is the number of log lines compressed together if no chunk size is set
+	DefaultLogArchiveChunkLines = 1000
+)
+
+// LogArchiveOptions controls how a log archive is written
+type LogArchiveOptions struct {
+	// ChunkLines is the maximum number of log lines compressed together; smaller chunks make reading line ranges cheaper at the cost of compression
+	ChunkLines int `json:"chunkLines,omitempty"`
+}
+
+// LogArchiveIndex is stored at the end of a log archive and describes where the log lines of every step can be found
+type LogArchiveIndex struct {
+	// Header holds the log without its steps
+	Header JobLogEnvelope   `json:"header"`
+	Steps  []LogArchiveStep `json:"steps"`
+}
+
+// LogArchiveStep describes a step, nested step or service in a log archive, in the same depth-first order as they're nested
+type LogArchiveStep struct {
+	// Path holds the names of the parent steps and the step itself, like [build lint]
+	Path     []string `json:"path"`
+	Type     LogType  `json:"type"`
+	RunIndex int      `json:"runIndex,omitempty"`
+	// Step holds the step without its log lines, nested steps and services
+	Step      *BuildLogStep     `json:"step"`
+	LineCount int               `json:"lineCount"`
+	Chunks    []LogArchiveChunk `json:"chunks,omitempty"`
+}
+
+// LogArchiveChunk is a compressed range of log lines of a single step
+type LogArchiveChunk struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+	// FirstLine is the index of the first line of the chunk within the log lines of the step
+	FirstLine      int       `json:"firstLine"`
+	LineCount      int       `json:"lineCount"`
+	FirstTimestamp time.Time `json:"firstTimestamp"`
+	LastTimestamp  time.Time `json:"lastTimestamp"`
+}
+
+// GetStepPath returns the path of the step, like build/lint
+func (step *LogArchiveStep) GetStepPath() string {
+	return strings.Join(step.Path, "/")
+}
+
+// WriteLogArchive writes the log as a log archive: a gzip compressed chunk of log lines per step followed by a compressed index and a fixed size footer pointing at the index
+func WriteLogArchive(w io.Writer, log JobLog, options LogArchiveOptions) (err error) {
+	if options.ChunkLines <= 0 {
+		options.ChunkLines = DefaultLogArchiveChunkLines
+	}
+
+	header, err := getJobLogWithoutSteps(log)
+	if err != nil {
+		return
+	}
+
+	archive := &logArchiveWriter{w: w}
+	if err = archive.write(logArchiveMagic); err != nil {
+		return
+	}
+
+	index := LogArchiveIndex{
+		Header: NewJobLogEnvelope(header),
+		Steps:  []LogArchiveStep{},
+	}
+
+	walkBuildLogSteps(log.GetSteps(), func(path []string, step *BuildLogStep, logType LogType) {
+		if err != nil {
+			return
+		}
+
+		archiveStep := LogArchiveStep{
+			Path:      path,
+			Type:      logType,
+			RunIndex:  step.RunIndex,
+			Step:      getBuildLogStepWithoutChildren(step),
+			LineCount: len(step.LogLines),
+		}
+
+		for i := 0; i < len(step.LogLines); i += options.ChunkLines {
+			end := i + options.ChunkLines
+			if end > len(step.LogLines) {
+				end = len(step.LogLines)
+			}
+
+			var chunk LogArchiveChunk
+			chunk, err = archive.writeChunk(step.LogLines[i:end])
+			if err != nil {
+				return
+			}
+			chunk.FirstLine = i
+			archiveStep.Chunks = append(archiveStep.Chunks, chunk)
+		}
+
+		index.Steps = append(index.Steps, archiveStep)
+	})
+	if err != nil {
+		return
+	}
+
+	indexOffset := archive.offset
+	if err = archive.writeCompressed(func(gz io.Writer) error {
+		return json.NewEncoder(gz).Encode(index)
+	}); err != nil {
+		return
+	}
+
+	footer := make([]byte, logArchiveFooterLength)
+	binary.BigEndian.PutUint64(footer[0:8], uint64(indexOffset))
+	binary.BigEndian.PutUint64(footer[8:16], uint64(archive.offset-indexOffset))
+	copy(footer[16:], logArchiveMagic)
+
+	return archive.write(footer)
+}
+
+type logArchiveWriter struct {
+	w      io.Writer
+	offset int64
+}
+
+func (a *logArchiveWriter) write(p []byte) error {
+	n, err := a.w.Write(p)
+	a.offset += int64(n)
+
+	return err
+}
+
+func (a *logArchiveWriter) writeCompressed(fn func(gz io.Writer) error) error {
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	if err := fn(gz); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return a.write(buffer.Bytes())
+}
+
+func (a *logArchiveWriter) writeChunk(lines []BuildLogLine) (LogArchiveChunk, error) {
+	chunk := LogArchiveChunk{
+		Offset:         a.offset,
+		LineCount:      len(lines),
+		FirstTimestamp: lines[0].Timestamp,
+		LastTimestamp:  lines[len(lines)-1].Timestamp,
+	}
+
+	err := a.writeCompressed(func(gz io.Writer) error {
+		encoder := json.NewEncoder(gz)
+		encoder.SetEscapeHTML(false)
+		for _, l := range lines {
+			if err := encoder.Encode(l); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	chunk.Length = a.offset - chunk.Offset
+
+	return chunk, err
+}
+
+// LogArchiveReader reads steps and line ranges from a log archive, only decompressing the chunks that are needed
+type LogArchiveReader struct {
+	r     io.ReaderAt
+	index LogArchiveIndex
+	// indexOffset is where the index starts, so it's also where the chunks end
+	indexOffset int64
+}
+
+// OpenLogArchive reads the index of the log archive of the given size; it returns an error if the index is corrupt
+func OpenLogArchive(r io.ReaderAt, size int64) (*LogArchiveReader, error) {
+	if size < int64(len(logArchiveMagic)+logArchiveFooterLength) {
+		return nil, errors.New("log archive is too small")
+	}
+
+	magic := make([]byte, len(logArchiveMagic))
+	if _, err := r.ReadAt(magic, 0); err != nil {
+		return nil, err
+	}
+	footer := make([]byte, logArchiveFooterLength)
+	if _, err := r.ReadAt(footer, size-logArchiveFooterLength); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(magic, logArchiveMagic) || !bytes.Equal(footer[16:], logArchiveMagic) {
+		return nil, errors.New("log archive has an invalid magic number")
+	}
+
+	indexOffset := int64(binary.BigEndian.Uint64(footer[0:8]))
+	indexLength := int64(binary.BigEndian.Uint64(footer[8:16]))
+	if indexOffset < int64(len(logArchiveMagic)) || indexLength <= 0 || indexOffset+indexLength > size-logArchiveFooterLength {
+		return nil, fmt.Errorf("log archive has an invalid index offset %v and length %v", indexOffset, indexLength)
+	}
+
+	reader := &LogArchiveReader{r: r, indexOffset: indexOffset}
+	if err := reader.readCompressed(indexOffset, indexLength, func(gz io.Reader) error {
+		return json.NewDecoder(gz).Decode(&reader.index)
+	}); err != nil {
+		return nil, err
+	}
+	if reader.index.Header.Log == nil {
+		return nil, errors.New("log archive index has no header")
+	}
+	if err := reader.validateIndex(); err != nil {
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// validateIndex checks that every step has chunks covering its lines in order and that all chunks lie between the
+// magic number and the index, so a corrupt index can't cause huge allocations or reads outside the archive
+func (reader *LogArchiveReader) validateIndex() error {
+	for _, s := range reader.index.Steps {
+		if s.Step == nil {
+			return fmt.Errorf("log archive step %v has no step", s.GetStepPath())
+		}
+		if s.LineCount < 0 {
+			return fmt.Errorf("log archive step %v has a negative line count %v", s.GetStepPath(), s.LineCount)
+		}
+
+		lineCount := 0
+		for _, c := range s.Chunks {
+			if err := reader.validateChunk(c); err != nil {
+				return fmt.Errorf("log archive step %v: %w", s.GetStepPath(), err)
+			}
+			if c.FirstLine != lineCount {
+				return fmt.Errorf("log archive step %v has a chunk starting at line %v instead of %v", s.GetStepPath(), c.FirstLine, lineCount)
+			}
+			lineCount += c.LineCount
+		}
+		if lineCount != s.LineCount {
+			return fmt.Errorf("log archive step %v has chunks with %v lines instead of %v", s.GetStepPath(), lineCount, s.LineCount)
+		}
+	}
+
+	return nil
+}
+
+// validateChunk checks that the chunk has lines and lies between the magic number and the index
+func (reader *LogArchiveReader) validateChunk(chunk LogArchiveChunk) error {
+	if chunk.LineCount <= 0 {
+		return fmt.Errorf("chunk at offset %v has an invalid line count %v", chunk.Offset, chunk.LineCount)
+	}
+	if chunk.Offset < int64(len(logArchiveMagic)) || chunk.Length <= 0 || chunk.Offset > reader.indexOffset || chunk.Length > reader.indexOffset-chunk.Offset {
+		return fmt.Errorf("chunk has an invalid offset %v and length %v", chunk.Offset, chunk.Length)
+	}
+
+	return nil
+}
+
+// Index returns the index of the log archive
+func (reader *LogArchiveReader) Index() LogArchiveIndex {
+	return reader.index
+}
+
+// FindStep returns the first step in the archive with the path, like build/lint, and run index
+func (reader *LogArchiveReader) FindStep(stepPath string, runIndex int) (LogArchiveStep, bool) {
+	for _, s := range reader.index.Steps {
+		if s.GetStepPath() == stepPath && s.RunIndex == runIndex {
+			return s, true
+		}
+	}
+
+	return LogArchiveStep{}, false
+}
+
+// ReadStepLines returns all log lines of the step
+func (reader *LogArchiveReader) ReadStepLines(step LogArchiveStep) ([]BuildLogLine, error) {
+	return reader.ReadLines(step, 0, step.LineCount)
+}
+
+// ReadLines returns the log lines of the step from index from up to but not including index to
+func (reader *LogArchiveReader) ReadLines(step LogArchiveStep, from, to int) ([]BuildLogLine, error) {
+	if from < 0 {
+		from = 0
+	}
+	if to > step.LineCount {
+		to = step.LineCount
+	}
+
+	lines := []BuildLogLine{}
+	for _, c := range step.Chunks {
+		if c.FirstLine+c.LineCount <= from || c.FirstLine >= to {
+			continue
+		}
+
+		chunkLines, err := reader.readChunk(c)
+		if err != nil {
+			return nil, err
+		}
+		for i, l := range chunkLines {
+			if c.FirstLine+i >= from && c.FirstLine+i < to {
+				lines = append(lines, l)
+			}
+		}
+	}
+
+	return lines, nil
+}
+
+// ReadLog decompresses all chunks and returns the full log
+func (reader *LogArchiveReader) ReadLog() (JobLog, error) {
+	builder := NewLogBuilder()
+
+	header := reader.index.Header
+	if err := builder.Add(&LogRecord{RecordType: LogRecordTypeHeader, Header: &header}); err != nil {
+		return nil, err
+	}
+
+	for _, s := range reader.index.Steps {
+		step := *s.Step
+		if err := builder.Add(&LogRecord{RecordType: LogRecordTypeStep, Path: s.Path, Type: s.Type, RunIndex: s.RunIndex, Step: &step}); err != nil {
+			return nil, err
+		}
+
+		lines, err := reader.ReadStepLines(s)
+		if err != nil {
+			return nil, err
+		}
+		for i := range lines {
+			if err := builder.Add(&LogRecord{RecordType: LogRecordTypeLine, Path: s.Path, Type: s.Type, RunIndex: s.RunIndex, Line: &lines[i]}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return builder.Log(), nil
+}
+
+func (reader *LogArchiveReader) readChunk(chunk LogArchiveChunk) ([]BuildLogLine, error) {
+	// steps passed to ReadLines don't necessarily come from the validated index
+	if err := reader.validateChunk(chunk); err != nil {
+		return nil, fmt.Errorf("log archive %w", err)
+	}
+
+	lines := []BuildLogLine{}
+	err := reader.readCompressed(chunk.Offset, chunk.Length, func(gz io.Reader) error {
+		decoder := json.NewDecoder(gz)
+		for len(lines) <= chunk.LineCount {
+			var line BuildLogLine
+			err := decoder.Decode(&line)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			lines = append(lines, line)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) != chunk.LineCount {
+		return nil, fmt.Errorf("log archive chunk at offset %v has %v lines instead of %v", chunk.Offset, len(lines), chunk.LineCount)
+	}
+
+	return lines, nil
+}
+
+func (reader *LogArchiveReader) readCompressed(offset, length int64, fn func(gz io.Reader) error) error {
+	gz, err := gzip.NewReader(io.NewSectionReader(reader.r, offset, length))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	return fn(gz)
+}
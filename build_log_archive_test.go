@@ -0,0 +1,212 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogArchive(t *testing.T) {
+	t.Run("RoundTripsBuildLog", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buffer bytes.Buffer
+		err := WriteLogArchive(&buffer, &buildLog, LogArchiveOptions{})
+		assert.Nil(t, err)
+
+		// act
+		reader, err := OpenLogArchive(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+
+		assert.Nil(t, err)
+		log, err := reader.ReadLog()
+		assert.Nil(t, err)
+		assert.Equal(t, &buildLog, log)
+	})
+
+	t.Run("RoundTripsReleaseLog", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		releaseLog := &ReleaseLog{ID: "6", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", ReleaseID: "2", Steps: buildLog.Steps, InsertedAt: buildLog.InsertedAt}
+		var buffer bytes.Buffer
+		err := WriteLogArchive(&buffer, releaseLog, LogArchiveOptions{})
+		assert.Nil(t, err)
+
+		// act
+		reader, err := OpenLogArchive(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+
+		assert.Nil(t, err)
+		log, err := reader.ReadLog()
+		assert.Nil(t, err)
+		assert.Equal(t, releaseLog, log)
+	})
+
+	t.Run("IndexesStepsWithLineRangesAndTimestamps", func(t *testing.T) {
+
+		step := getTruncationStep("build", 25)
+		buildLog := &BuildLog{BuildID: "1", Steps: []*BuildLogStep{step}}
+		var buffer bytes.Buffer
+		err := WriteLogArchive(&buffer, buildLog, LogArchiveOptions{ChunkLines: 10})
+		assert.Nil(t, err)
+
+		// act
+		reader, err := OpenLogArchive(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+
+		assert.Nil(t, err)
+		index := reader.Index()
+		assert.Equal(t, JobTypeBuild, index.Header.JobType)
+		assert.Equal(t, 1, len(index.Steps))
+		assert.Equal(t, "build", index.Steps[0].GetStepPath())
+		assert.Equal(t, 25, index.Steps[0].LineCount)
+		if assert.Equal(t, 3, len(index.Steps[0].Chunks)) {
+			assert.Equal(t, 20, index.Steps[0].Chunks[2].FirstLine)
+			assert.Equal(t, 5, index.Steps[0].Chunks[2].LineCount)
+			assert.Equal(t, step.LogLines[20].Timestamp, index.Steps[0].Chunks[2].FirstTimestamp)
+			assert.Equal(t, step.LogLines[24].Timestamp, index.Steps[0].Chunks[2].LastTimestamp)
+		}
+	})
+
+	t.Run("ReadsLineRangeOnlyFromOverlappingChunks", func(t *testing.T) {
+
+		step := getTruncationStep("build", 25)
+		buildLog := &BuildLog{BuildID: "1", Steps: []*BuildLogStep{step}}
+		var buffer bytes.Buffer
+		err := WriteLogArchive(&buffer, buildLog, LogArchiveOptions{ChunkLines: 10})
+		assert.Nil(t, err)
+		data := buffer.Bytes()
+		reader, err := OpenLogArchive(bytes.NewReader(data), int64(len(data)))
+		assert.Nil(t, err)
+		archiveStep, ok := reader.FindStep("build", 0)
+		assert.True(t, ok)
+
+		// corrupt the first chunk to prove it isn't read
+		firstChunk := archiveStep.Chunks[0]
+		for i := firstChunk.Offset; i < firstChunk.Offset+firstChunk.Length; i++ {
+			data[i] = 0
+		}
+
+		// act
+		lines, err := reader.ReadLines(archiveStep, 12, 22)
+
+		assert.Nil(t, err)
+		assert.Equal(t, step.LogLines[12:22], lines)
+	})
+
+	t.Run("FindsStepsByPathAndRunIndex", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buffer bytes.Buffer
+		err := WriteLogArchive(&buffer, &buildLog, LogArchiveOptions{})
+		assert.Nil(t, err)
+		reader, err := OpenLogArchive(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		assert.Nil(t, err)
+
+		// act
+		service, okService := reader.FindStep("build/postgres", 0)
+		retry, okRetry := reader.FindStep("test", 1)
+		_, okMissing := reader.FindStep("deploy", 0)
+
+		assert.True(t, okService)
+		assert.Equal(t, LogTypeService, service.Type)
+		assert.True(t, okRetry)
+		lines, err := reader.ReadStepLines(retry)
+		assert.Nil(t, err)
+		assert.Equal(t, []BuildLogLine{BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 20, 0, time.UTC), StreamType: "stdout", Text: "PASS"}}, lines)
+		assert.False(t, okMissing)
+	})
+
+	t.Run("ReturnsErrorForInvalidArchive", func(t *testing.T) {
+
+		data := []byte(fmt.Sprintf("%040d", 0))
+
+		// act
+		_, err := OpenLogArchive(bytes.NewReader(data), int64(len(data)))
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ReturnsErrorForTamperedIndex", func(t *testing.T) {
+
+		tamperings := map[string]func(index *LogArchiveIndex){
+			"NegativeLineCount": func(index *LogArchiveIndex) {
+				index.Steps[0].LineCount = -1
+				index.Steps[0].Chunks[0].LineCount = -1
+			},
+			"HugeLineCount": func(index *LogArchiveIndex) {
+				index.Steps[0].LineCount = 1 << 40
+				index.Steps[0].Chunks[0].LineCount = 1 << 40
+			},
+			"LineCountNotMatchingChunks": func(index *LogArchiveIndex) { index.Steps[0].LineCount = 26 },
+			"NegativeOffset":             func(index *LogArchiveIndex) { index.Steps[0].Chunks[0].Offset = -1 },
+			"ZeroLength":                 func(index *LogArchiveIndex) { index.Steps[0].Chunks[0].Length = 0 },
+			"LengthBeyondArchive":        func(index *LogArchiveIndex) { index.Steps[0].Chunks[0].Length = 1 << 62 },
+			"GapBetweenChunks":           func(index *LogArchiveIndex) { index.Steps[0].Chunks[1].FirstLine = 11 },
+			"MissingStep":                func(index *LogArchiveIndex) { index.Steps[0].Step = nil },
+		}
+
+		for name, tamper := range tamperings {
+			data := getTamperedLogArchive(t, tamper)
+
+			// act
+			_, err := OpenLogArchive(bytes.NewReader(data), int64(len(data)))
+
+			assert.NotNil(t, err, name)
+		}
+	})
+
+	t.Run("ReturnsErrorForChunkOutsideArchiveInReadLines", func(t *testing.T) {
+
+		data := getTamperedLogArchive(t, func(index *LogArchiveIndex) {})
+		reader, err := OpenLogArchive(bytes.NewReader(data), int64(len(data)))
+		assert.Nil(t, err)
+		step, _ := reader.FindStep("build", 0)
+		step.Chunks[0].Offset = -5
+
+		// act
+		_, err = reader.ReadLines(step, 0, 10)
+
+		assert.NotNil(t, err)
+	})
+}
+
+// getTamperedLogArchive writes a log archive for a step with 25 lines in chunks of 10 and rewrites its index after tampering with it
+func getTamperedLogArchive(t *testing.T, tamper func(index *LogArchiveIndex)) []byte {
+
+	var buffer bytes.Buffer
+	err := WriteLogArchive(&buffer, &BuildLog{BuildID: "1", Steps: []*BuildLogStep{getTruncationStep("build", 25)}}, LogArchiveOptions{ChunkLines: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := OpenLogArchive(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := reader.Index()
+	index.Steps = append([]LogArchiveStep{}, index.Steps...)
+	index.Steps[0].Chunks = append([]LogArchiveChunk{}, index.Steps[0].Chunks...)
+	tamper(&index)
+
+	archive := &logArchiveWriter{w: &bytes.Buffer{}}
+	archive.w.(*bytes.Buffer).Write(buffer.Bytes()[:reader.indexOffset])
+	archive.offset = reader.indexOffset
+	if err := archive.writeCompressed(func(gz io.Writer) error {
+		return json.NewEncoder(gz).Encode(index)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	footer := make([]byte, logArchiveFooterLength)
+	binary.BigEndian.PutUint64(footer[0:8], uint64(reader.indexOffset))
+	binary.BigEndian.PutUint64(footer[8:16], uint64(archive.offset-reader.indexOffset))
+	copy(footer[16:], logArchiveMagic)
+	if err := archive.write(footer); err != nil {
+		t.Fatal(err)
+	}
+
+	return archive.w.(*bytes.Buffer).Bytes()
+}
@@ -0,0 +1,342 @@
+package contracts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type LogDiffChange string
+
+const (
+	// LogDiffChangeUnchanged indicates the step exists in both logs with the same outcome and log text
+	LogDiffChangeUnchanged LogDiffChange = "unchanged"
+	// LogDiffChangeChanged indicates the step exists in both logs but the outcome, image or log text differ
+	LogDiffChangeChanged LogDiffChange = "changed"
+	// LogDiffChangeAdded indicates the step only exists in the new log
+	LogDiffChangeAdded LogDiffChange = "added"
+	// LogDiffChangeRemoved indicates the step only exists in the old log
+	LogDiffChangeRemoved LogDiffChange = "removed"
+)
+
+type LogLineDiffOperation string
+
+const (
+	// LogLineDiffOperationEqual indicates the line is the same in both logs after normalisation
+	LogLineDiffOperationEqual LogLineDiffOperation = "equal"
+	// LogLineDiffOperationInsert indicates the line only exists in the new log
+	LogLineDiffOperationInsert LogLineDiffOperation = "insert"
+	// LogLineDiffOperationDelete indicates the line only exists in the old log
+	LogLineDiffOperationDelete LogLineDiffOperation = "delete"
+)
+
+// maxLogLineDiffCells limits the memory used for diffing the log lines of a single step; when the lines that differ exceed it all old lines are reported as deleted and all new lines as inserted
+const maxLogLineDiffCells = 4000000
+
+// LogDiff is the structural difference between two build, release or bot logs
+type LogDiff struct {
+	Steps     []LogStepDiff `json:"steps"`
+	Added     int           `json:"added"`
+	Removed   int           `json:"removed"`
+	Changed   int           `json:"changed"`
+	Unchanged int           `json:"unchanged"`
+}
+
+// LogStepDiff is the difference for a step aligned by step path and run index
+type LogStepDiff struct {
+	StepPath      string        `json:"stepPath"`
+	Type          LogType       `json:"type"`
+	RunIndex      int           `json:"runIndex,omitempty"`
+	Change        LogDiffChange `json:"change"`
+	OldStatus     LogStatus     `json:"oldStatus,omitempty"`
+	NewStatus     LogStatus     `json:"newStatus,omitempty"`
+	OldExitCode   int64         `json:"oldExitCode"`
+	NewExitCode   int64         `json:"newExitCode"`
+	OldDuration   time.Duration `json:"oldDuration"`
+	NewDuration   time.Duration `json:"newDuration"`
+	DurationDelta time.Duration `json:"durationDelta"`
+	OldImage      string        `json:"oldImage,omitempty"`
+	NewImage      string        `json:"newImage,omitempty"`
+	Lines         []LogLineDiff `json:"lines,omitempty"`
+}
+
+// LogLineDiff is a single line in the line level diff of a step; line numbers are 0 for the log the line doesn't exist in
+type LogLineDiff struct {
+	Operation     LogLineDiffOperation `json:"operation"`
+	OldLineNumber int                  `json:"oldLine,omitempty"`
+	NewLineNumber int                  `json:"newLine,omitempty"`
+	Text          string               `json:"text"`
+}
+
+// HasChanges returns true if any step was added, removed or changed
+func (diff LogDiff) HasChanges() bool {
+	return diff.Added+diff.Removed+diff.Changed > 0
+}
+
+// HasStatusChange returns true if the status or exit code of the step differ
+func (diff LogStepDiff) HasStatusChange() bool {
+	return diff.OldStatus != diff.NewStatus || diff.OldExitCode != diff.NewExitCode
+}
+
+// HasImageChange returns true if the image name or tag of the step differ
+func (diff LogStepDiff) HasImageChange() bool {
+	return diff.OldImage != diff.NewImage
+}
+
+// DiffJobLogs returns the difference between a previous log and the current log of the same pipeline; a nil previous
+// log, as for the first build of a pipeline, is treated as a log without steps
+func DiffJobLogs(previous, current JobLog) LogDiff {
+	var previousSteps, currentSteps []*BuildLogStep
+	if previous != nil {
+		previousSteps = previous.GetSteps()
+	}
+	if current != nil {
+		currentSteps = current.GetSteps()
+	}
+
+	return DiffSteps(previousSteps, currentSteps)
+}
+
+// DiffSteps aligns steps, nested steps and services of both logs by step path and run index and returns their differences;
+// steps are returned in the order of the new log followed by the steps that were removed. Unchanged steps are returned
+// without lines to keep the diff small
+func DiffSteps(oldSteps, newSteps []*BuildLogStep) LogDiff {
+
+	type alignedStep struct {
+		path    string
+		logType LogType
+		step    *BuildLogStep
+	}
+
+	collect := func(steps []*BuildLogStep) ([]string, map[string]alignedStep) {
+		keys := []string{}
+		aligned := map[string]alignedStep{}
+		walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+			stepPath := strings.Join(path, "/")
+			key := fmt.Sprintf("%v#%v", stepPath, step.RunIndex)
+			if _, ok := aligned[key]; !ok {
+				keys = append(keys, key)
+			}
+			aligned[key] = alignedStep{path: stepPath, logType: logType, step: step}
+		})
+		return keys, aligned
+	}
+
+	oldKeys, oldAligned := collect(oldSteps)
+	newKeys, newAligned := collect(newSteps)
+
+	diff := LogDiff{
+		Steps: []LogStepDiff{},
+	}
+
+	for _, key := range newKeys {
+		n := newAligned[key]
+		stepDiff := LogStepDiff{
+			StepPath:    n.path,
+			Type:        n.logType,
+			RunIndex:    n.step.RunIndex,
+			NewStatus:   n.step.Status,
+			NewExitCode: n.step.ExitCode,
+			NewDuration: n.step.Duration,
+			NewImage:    getDiffImage(n.step.Image),
+		}
+
+		o, ok := oldAligned[key]
+		if !ok {
+			stepDiff.Change = LogDiffChangeAdded
+			stepDiff.DurationDelta = n.step.Duration
+			stepDiff.Lines = diffLogLines(nil, n.step.LogLines)
+			diff.Added++
+			diff.Steps = append(diff.Steps, stepDiff)
+			continue
+		}
+
+		stepDiff.OldStatus = o.step.Status
+		stepDiff.OldExitCode = o.step.ExitCode
+		stepDiff.OldDuration = o.step.Duration
+		stepDiff.OldImage = getDiffImage(o.step.Image)
+		stepDiff.DurationDelta = n.step.Duration - o.step.Duration
+		stepDiff.Lines = diffLogLines(o.step.LogLines, n.step.LogLines)
+
+		stepDiff.Change = LogDiffChangeUnchanged
+		if stepDiff.HasStatusChange() || stepDiff.HasImageChange() || hasLogLineChanges(stepDiff.Lines) {
+			stepDiff.Change = LogDiffChangeChanged
+			diff.Changed++
+		} else {
+			stepDiff.Lines = nil
+			diff.Unchanged++
+		}
+
+		diff.Steps = append(diff.Steps, stepDiff)
+	}
+
+	for _, key := range oldKeys {
+		if _, ok := newAligned[key]; ok {
+			continue
+		}
+
+		o := oldAligned[key]
+		diff.Steps = append(diff.Steps, LogStepDiff{
+			StepPath:      o.path,
+			Type:          o.logType,
+			RunIndex:      o.step.RunIndex,
+			Change:        LogDiffChangeRemoved,
+			OldStatus:     o.step.Status,
+			OldExitCode:   o.step.ExitCode,
+			OldDuration:   o.step.Duration,
+			DurationDelta: -o.step.Duration,
+			OldImage:      getDiffImage(o.step.Image),
+			Lines:         diffLogLines(o.step.LogLines, nil),
+		})
+		diff.Removed++
+	}
+
+	return diff
+}
+
+var (
+	logNoiseTimestampRegex = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?|\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`)
+	logNoiseUUIDRegex      = regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`)
+	logNoiseHashRegex      = regexp.MustCompile(`\b[0-9a-fA-F]{7,64}\b`)
+	logNoiseDurationRegex  = regexp.MustCompile(`\b(\d+(\.\d+)?(h|m|s|ms|us|µs|ns))+\b`)
+	logNoiseDecimalRegex   = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// NormalizeLogText replaces timestamps, uuids, hashes and durations in a log line with placeholders so lines of different runs can be compared
+func NormalizeLogText(text string) string {
+
+	text = logNoiseTimestampRegex.ReplaceAllString(text, "<timestamp>")
+	text = logNoiseUUIDRegex.ReplaceAllString(text, "<uuid>")
+	text = logNoiseHashRegex.ReplaceAllStringFunc(text, func(match string) string {
+		// leave plain numbers alone, they're more likely to be meaningful than a hash
+		if logNoiseDecimalRegex.MatchString(match) {
+			return match
+		}
+		return "<hash>"
+	})
+	text = logNoiseDurationRegex.ReplaceAllString(text, "<duration>")
+
+	return text
+}
+
+func diffLogLines(oldLines, newLines []BuildLogLine) []LogLineDiff {
+
+	a := make([]string, len(oldLines))
+	for i, l := range oldLines {
+		a[i] = NormalizeLogText(l.Text)
+	}
+	b := make([]string, len(newLines))
+	for i, l := range newLines {
+		b[i] = NormalizeLogText(l.Text)
+	}
+
+	// strip common prefix and suffix to keep the part that needs the expensive diff small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]LogLineDiff, 0, len(a)+len(b)-prefix-suffix)
+	equal := func(i, j int) {
+		lines = append(lines, LogLineDiff{Operation: LogLineDiffOperationEqual, OldLineNumber: getDiffLineNumber(oldLines, i), NewLineNumber: getDiffLineNumber(newLines, j), Text: newLines[j].Text})
+	}
+	del := func(i int) {
+		lines = append(lines, LogLineDiff{Operation: LogLineDiffOperationDelete, OldLineNumber: getDiffLineNumber(oldLines, i), Text: oldLines[i].Text})
+	}
+	ins := func(j int) {
+		lines = append(lines, LogLineDiff{Operation: LogLineDiffOperationInsert, NewLineNumber: getDiffLineNumber(newLines, j), Text: newLines[j].Text})
+	}
+
+	for i := 0; i < prefix; i++ {
+		equal(i, i)
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	if len(midA)*len(midB) > maxLogLineDiffCells {
+		for i := range midA {
+			del(prefix + i)
+		}
+		for j := range midB {
+			ins(prefix + j)
+		}
+	} else {
+		// longest common subsequence, computed from the end so it can be walked forward
+		n, m := len(midA), len(midB)
+		lcs := make([][]int, n+1)
+		for i := range lcs {
+			lcs[i] = make([]int, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if midA[i] == midB[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else if lcs[i+1][j] >= lcs[i][j+1] {
+					lcs[i][j] = lcs[i+1][j]
+				} else {
+					lcs[i][j] = lcs[i][j+1]
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < n && j < m {
+			switch {
+			case midA[i] == midB[j]:
+				equal(prefix+i, prefix+j)
+				i++
+				j++
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				del(prefix + i)
+				i++
+			default:
+				ins(prefix + j)
+				j++
+			}
+		}
+		for ; i < n; i++ {
+			del(prefix + i)
+		}
+		for ; j < m; j++ {
+			ins(prefix + j)
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		equal(len(a)-suffix+k, len(b)-suffix+k)
+	}
+
+	return lines
+}
+
+func hasLogLineChanges(lines []LogLineDiff) bool {
+	for _, l := range lines {
+		if l.Operation != LogLineDiffOperationEqual {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getDiffLineNumber(lines []BuildLogLine, i int) int {
+	if lines[i].LineNumber > 0 {
+		return lines[i].LineNumber
+	}
+
+	return i + 1
+}
+
+func getDiffImage(image *BuildLogStepDockerImage) string {
+	if image == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v:%v", image.Name, image.Tag)
+}
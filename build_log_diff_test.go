@@ -0,0 +1,194 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSteps(t *testing.T) {
+	t.Run("ReturnsUnchangedForIdenticalLogs", func(t *testing.T) {
+
+		previous := getExportBuildLog()
+		current := getExportBuildLog()
+
+		// act
+		diff := DiffJobLogs(&previous, &current)
+
+		assert.False(t, diff.HasChanges())
+		assert.Equal(t, 5, diff.Unchanged)
+		assert.Equal(t, 5, len(diff.Steps))
+		assert.Equal(t, "build/postgres", diff.Steps[2].StepPath)
+		assert.Equal(t, LogTypeService, diff.Steps[2].Type)
+		assert.Nil(t, diff.Steps[0].Lines)
+	})
+
+	t.Run("TreatsNilPreviousLogAsEmpty", func(t *testing.T) {
+
+		current := getExportBuildLog()
+		var previousReleaseLog *ReleaseLog
+		releaseLog := &ReleaseLog{Steps: current.Steps}
+
+		// act
+		diff := DiffJobLogs(nil, &current)
+		releaseDiff := DiffJobLogs(previousReleaseLog, releaseLog)
+
+		assert.Equal(t, 5, diff.Added)
+		assert.Equal(t, 0, diff.Removed)
+		assert.Equal(t, len(current.Steps[0].LogLines), len(diff.Steps[0].Lines))
+		assert.Equal(t, 5, releaseDiff.Added)
+	})
+
+	t.Run("AlignsStepsByNameAndRunIndex", func(t *testing.T) {
+
+		previous := getExportBuildLog()
+		current := getExportBuildLog()
+		// the retry isn't needed this time
+		current.Steps[1].ExitCode = 0
+		current.Steps[1].Status = LogStatusSucceeded
+		current.Steps = current.Steps[:2]
+
+		// act
+		diff := DiffJobLogs(&previous, &current)
+
+		assert.True(t, diff.HasChanges())
+		assert.Equal(t, 1, diff.Changed)
+		assert.Equal(t, 1, diff.Removed)
+		if assert.Equal(t, 5, len(diff.Steps)) {
+			assert.Equal(t, "test", diff.Steps[3].StepPath)
+			assert.Equal(t, LogDiffChangeChanged, diff.Steps[3].Change)
+			assert.True(t, diff.Steps[3].HasStatusChange())
+			assert.Equal(t, LogStatusFailed, diff.Steps[3].OldStatus)
+			assert.Equal(t, LogStatusSucceeded, diff.Steps[3].NewStatus)
+			assert.Equal(t, int64(1), diff.Steps[3].OldExitCode)
+			assert.Equal(t, int64(0), diff.Steps[3].NewExitCode)
+
+			assert.Equal(t, "test", diff.Steps[4].StepPath)
+			assert.Equal(t, 1, diff.Steps[4].RunIndex)
+			assert.Equal(t, LogDiffChangeRemoved, diff.Steps[4].Change)
+			assert.Equal(t, -8*time.Second, diff.Steps[4].DurationDelta)
+		}
+	})
+
+	t.Run("ReturnsAddedStepsAndImageChanges", func(t *testing.T) {
+
+		previous := getExportBuildLog()
+		current := getExportBuildLog()
+		current.Steps[0].Image = &BuildLogStepDockerImage{Name: "golang", Tag: "1.22"}
+		current.Steps = append(current.Steps, &BuildLogStep{Step: "push", Status: LogStatusSucceeded, Duration: 3 * time.Second})
+
+		// act
+		diff := DiffJobLogs(&previous, &current)
+
+		assert.Equal(t, 1, diff.Added)
+		assert.Equal(t, 1, diff.Changed)
+		assert.Equal(t, LogDiffChangeChanged, diff.Steps[0].Change)
+		assert.True(t, diff.Steps[0].HasImageChange())
+		assert.Equal(t, "golang:1.22", diff.Steps[0].NewImage)
+		assert.Equal(t, LogDiffChangeAdded, diff.Steps[5].Change)
+		assert.Equal(t, 3*time.Second, diff.Steps[5].DurationDelta)
+	})
+
+	t.Run("IgnoresDurationChangesAndNoise", func(t *testing.T) {
+
+		oldSteps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:     "test",
+				Duration: 10 * time.Second,
+				LogLines: []BuildLogLine{
+					BuildLogLine{Text: "ok  	github.com/ziplineeci/ziplinee-ci-contracts	0.017s"},
+					BuildLogLine{Text: "Successfully built 3f9a2c1b7d"},
+				},
+			},
+		}
+		newSteps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:     "test",
+				Duration: 12 * time.Second,
+				LogLines: []BuildLogLine{
+					BuildLogLine{Text: "ok  	github.com/ziplineeci/ziplinee-ci-contracts	0.021s"},
+					BuildLogLine{Text: "Successfully built 8e1d0a4c2f"},
+				},
+			},
+		}
+
+		// act
+		diff := DiffSteps(oldSteps, newSteps)
+
+		assert.False(t, diff.HasChanges())
+		assert.Equal(t, 2*time.Second, diff.Steps[0].DurationDelta)
+	})
+
+	t.Run("ReturnsLineLevelDiff", func(t *testing.T) {
+
+		oldSteps := []*BuildLogStep{
+			&BuildLogStep{
+				Step: "test",
+				LogLines: []BuildLogLine{
+					BuildLogLine{LineNumber: 1, Text: "=== RUN TestA"},
+					BuildLogLine{LineNumber: 2, Text: "--- PASS: TestA (0.00s)"},
+					BuildLogLine{LineNumber: 3, Text: "PASS"},
+				},
+			},
+		}
+		newSteps := []*BuildLogStep{
+			&BuildLogStep{
+				Step: "test",
+				LogLines: []BuildLogLine{
+					BuildLogLine{LineNumber: 1, Text: "=== RUN TestA"},
+					BuildLogLine{LineNumber: 2, Text: "--- FAIL: TestA (0.01s)"},
+					BuildLogLine{LineNumber: 3, Text: "    a_test.go:12: expected 1, got 2"},
+					BuildLogLine{LineNumber: 4, Text: "FAIL"},
+				},
+			},
+		}
+
+		// act
+		diff := DiffSteps(oldSteps, newSteps)
+
+		assert.Equal(t, LogDiffChangeChanged, diff.Steps[0].Change)
+		assert.Equal(t, []LogLineDiff{
+			{Operation: LogLineDiffOperationEqual, OldLineNumber: 1, NewLineNumber: 1, Text: "=== RUN TestA"},
+			{Operation: LogLineDiffOperationDelete, OldLineNumber: 2, Text: "--- PASS: TestA (0.00s)"},
+			{Operation: LogLineDiffOperationDelete, OldLineNumber: 3, Text: "PASS"},
+			{Operation: LogLineDiffOperationInsert, NewLineNumber: 2, Text: "--- FAIL: TestA (0.01s)"},
+			{Operation: LogLineDiffOperationInsert, NewLineNumber: 3, Text: "    a_test.go:12: expected 1, got 2"},
+			{Operation: LogLineDiffOperationInsert, NewLineNumber: 4, Text: "FAIL"},
+		}, diff.Steps[0].Lines)
+	})
+}
+
+func TestNormalizeLogText(t *testing.T) {
+	t.Run("ReplacesTimestamps", func(t *testing.T) {
+
+		// act
+		text := NormalizeLogText("2018-04-17T08:03:00.123Z starting at 08:03:00")
+
+		assert.Equal(t, "<timestamp> starting at <timestamp>", text)
+	})
+
+	t.Run("ReplacesHashesAndUUIDs", func(t *testing.T) {
+
+		// act
+		text := NormalizeLogText("digest: sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08 id 123e4567-e89b-12d3-a456-426614174000")
+
+		assert.Equal(t, "digest: sha256:<hash> id <uuid>", text)
+	})
+
+	t.Run("ReplacesDurations", func(t *testing.T) {
+
+		// act
+		text := NormalizeLogText("took 1m30.5s, then 250ms")
+
+		assert.Equal(t, "took <duration>, then <duration>", text)
+	})
+
+	t.Run("KeepsPlainNumbers", func(t *testing.T) {
+
+		// act
+		text := NormalizeLogText("processed 12345678 records")
+
+		assert.Equal(t, "processed 12345678 records", text)
+	})
+}
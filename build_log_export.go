@@ -0,0 +1,236 @@
+package contracts
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// JUnitTestSuites is the root element of a JUnit XML report
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite groups the testcases for all steps of a single build, release or bot log
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase represents a single step, nested step or service
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+	SystemErr string        `xml:"system-err,omitempty"`
+}
+
+// JUnitFailure marks a testcase as failed
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitSkipped marks a testcase as skipped
+type JUnitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// WriteJobLogJUnitXML writes the build, release or bot log as JUnit XML with every step as a testcase
+func WriteJobLogJUnitXML(w io.Writer, log JobLog) error {
+	return WriteJUnitXML(w, log.GetFullRepoPath(), log.GetInsertedAt(), log.GetSteps())
+}
+
+// WriteJobLogPlainText writes the build, release or bot log as a timestamped plain text transcript
+func WriteJobLogPlainText(w io.Writer, log JobLog) error {
+	return WritePlainText(w, log.GetSteps())
+}
+
+// WriteJobLogGroupedText writes the build, release or bot log as text folded with ::group:: and ::endgroup:: markers
+func WriteJobLogGroupedText(w io.Writer, log JobLog) error {
+	return WriteGroupedText(w, log.GetSteps())
+}
+
+// GetJUnitTestSuite returns a testsuite with a testcase for every step, nested step and service
+func GetJUnitTestSuite(name string, timestamp time.Time, steps []*BuildLogStep) JUnitTestSuite {
+
+	suite := JUnitTestSuite{
+		Name:      name,
+		TestCases: []JUnitTestCase{},
+	}
+	if !timestamp.IsZero() {
+		suite.Timestamp = timestamp.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	var totalDuration time.Duration
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+
+		// nested steps and services run within the duration of their parent
+		if len(path) == 1 {
+			totalDuration += step.Duration
+		}
+
+		testCase := JUnitTestCase{
+			Name:      getExportStepName(path, step),
+			ClassName: strings.Join(append([]string{name}, path[:len(path)-1]...), "/"),
+			Time:      formatJUnitDuration(step.Duration),
+		}
+		if logType == LogTypeService {
+			testCase.ClassName += "/services"
+		}
+
+		var stdout, stderr strings.Builder
+		for _, l := range step.LogLines {
+			if l.StreamType == StreamTypeStderr {
+				stderr.WriteString(l.Text)
+				stderr.WriteString("\n")
+			} else {
+				stdout.WriteString(l.Text)
+				stdout.WriteString("\n")
+			}
+		}
+		testCase.SystemOut = stdout.String()
+		testCase.SystemErr = stderr.String()
+
+		switch {
+		case step.Status == LogStatusSkipped:
+			testCase.Skipped = &JUnitSkipped{}
+			suite.Skipped++
+		case step.ExitCode != 0 || step.Status == LogStatusFailed:
+			testCase.Failure = &JUnitFailure{
+				Message: fmt.Sprintf("%v failed with exit code %v", testCase.Name, step.ExitCode),
+				Type:    string(step.Status),
+			}
+			if step.Image != nil && step.Image.Error != "" {
+				testCase.Failure.Text = step.Image.Error
+			}
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	})
+
+	suite.Tests = len(suite.TestCases)
+	suite.Time = formatJUnitDuration(totalDuration)
+
+	return suite
+}
+
+// WriteJUnitXML writes the steps as a JUnit XML report with a single testsuite
+func WriteJUnitXML(w io.Writer, name string, timestamp time.Time, steps []*BuildLogStep) error {
+
+	suite := GetJUnitTestSuite(name, timestamp, steps)
+
+	report := JUnitTestSuites{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []JUnitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WritePlainText writes all log lines prefixed with their timestamp, step path and stream type
+func WritePlainText(w io.Writer, steps []*BuildLogStep) (err error) {
+
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		if err != nil {
+			return
+		}
+
+		name := getExportStepName(path, step)
+		for _, l := range step.LogLines {
+			if _, err = fmt.Fprintf(w, "%v [%v] %v: %v\n", l.Timestamp.UTC().Format(time.RFC3339Nano), name, getExportStreamType(l), l.Text); err != nil {
+				return
+			}
+		}
+	})
+
+	return
+}
+
+// WriteGroupedText writes the log lines of every step inside a ::group:: / ::endgroup:: block; since most
+// log viewers don't support nested groups, nested steps and services get their own group named by their full path
+func WriteGroupedText(w io.Writer, steps []*BuildLogStep) (err error) {
+
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		if err != nil {
+			return
+		}
+
+		name := getExportStepName(path, step)
+		if logType == LogTypeService {
+			name += " (service)"
+		}
+
+		if _, err = fmt.Fprintf(w, "::group::%v\n", name); err != nil {
+			return
+		}
+		for _, l := range step.LogLines {
+			if _, err = fmt.Fprintf(w, "%v\n", l.Text); err != nil {
+				return
+			}
+		}
+		if _, err = io.WriteString(w, "::endgroup::\n"); err != nil {
+			return
+		}
+
+		if step.ExitCode != 0 || step.Status == LogStatusFailed {
+			_, err = fmt.Fprintf(w, "::error title=%v::%v failed with exit code %v\n", name, name, step.ExitCode)
+		}
+	})
+
+	return
+}
+
+func getExportStepName(path []string, step *BuildLogStep) string {
+	name := strings.Join(path, "/")
+	if step.RunIndex > 0 {
+		name = fmt.Sprintf("%v (run %v)", name, step.RunIndex+1)
+	}
+
+	return name
+}
+
+func getExportStreamType(line BuildLogLine) string {
+	if line.StreamType == "" {
+		return StreamTypeStdout
+	}
+
+	return line.StreamType
+}
+
+func formatJUnitDuration(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
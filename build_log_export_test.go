@@ -0,0 +1,203 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJUnitXML(t *testing.T) {
+	t.Run("ReturnsATestCaseForEveryStepNestedStepAndService", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buf bytes.Buffer
+
+		// act
+		err := WriteJobLogJUnitXML(&buf, &buildLog)
+
+		assert.Nil(t, err)
+		var report JUnitTestSuites
+		err = xml.Unmarshal(buf.Bytes(), &report)
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(report.Suites)) {
+			suite := report.Suites[0]
+			assert.Equal(t, "github.com/ziplineeci/ziplinee-ci-api", suite.Name)
+			assert.Equal(t, "2018-04-17T08:03:00", suite.Timestamp)
+			assert.Equal(t, 5, suite.Tests)
+			assert.Equal(t, "25.000", suite.Time)
+			if assert.Equal(t, 5, len(suite.TestCases)) {
+				assert.Equal(t, "build", suite.TestCases[0].Name)
+				assert.Equal(t, "github.com/ziplineeci/ziplinee-ci-api", suite.TestCases[0].ClassName)
+				assert.Equal(t, "build/lint", suite.TestCases[1].Name)
+				assert.Equal(t, "github.com/ziplineeci/ziplinee-ci-api/build", suite.TestCases[1].ClassName)
+				assert.Equal(t, "build/postgres", suite.TestCases[2].Name)
+				assert.Equal(t, "github.com/ziplineeci/ziplinee-ci-api/build/services", suite.TestCases[2].ClassName)
+				assert.Equal(t, "test", suite.TestCases[3].Name)
+				assert.Equal(t, "test (run 2)", suite.TestCases[4].Name)
+			}
+		}
+	})
+
+	t.Run("ReturnsFailureForStepsWithNonZeroExitCode", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		suite := GetJUnitTestSuite("suite", buildLog.InsertedAt, buildLog.Steps)
+
+		assert.Equal(t, 1, suite.Failures)
+		assert.Nil(t, suite.TestCases[0].Failure)
+		if assert.NotNil(t, suite.TestCases[3].Failure) {
+			assert.Equal(t, "test failed with exit code 1", suite.TestCases[3].Failure.Message)
+			assert.Equal(t, "FAILED", suite.TestCases[3].Failure.Type)
+		}
+		assert.Nil(t, suite.TestCases[4].Failure)
+	})
+
+	t.Run("ReturnsSkippedForSkippedSteps", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:   "deploy",
+				Status: LogStatusSkipped,
+			},
+		}
+
+		// act
+		suite := GetJUnitTestSuite("suite", time.Time{}, steps)
+
+		assert.Equal(t, 1, suite.Skipped)
+		assert.Equal(t, 0, suite.Failures)
+		assert.NotNil(t, suite.TestCases[0].Skipped)
+		assert.Equal(t, "", suite.Timestamp)
+	})
+
+	t.Run("SplitsLogLinesIntoSystemOutAndSystemErr", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		suite := GetJUnitTestSuite("suite", buildLog.InsertedAt, buildLog.Steps)
+
+		assert.Equal(t, "go build ./...\n", suite.TestCases[0].SystemOut)
+		assert.Equal(t, "warning: deprecated flag\n", suite.TestCases[0].SystemErr)
+	})
+}
+
+func TestWritePlainText(t *testing.T) {
+	t.Run("ReturnsTimestampedLinesForAllSteps", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buf bytes.Buffer
+
+		// act
+		err := WriteJobLogPlainText(&buf, &buildLog)
+
+		assert.Nil(t, err)
+		assert.Equal(t, `2018-04-17T08:03:01Z [build] stdout: go build ./...
+2018-04-17T08:03:02Z [build] stderr: warning: deprecated flag
+2018-04-17T08:03:01Z [build/lint] stdout: golint ./...
+2018-04-17T08:03:00Z [build/postgres] stdout: database system is ready to accept connections
+2018-04-17T08:03:12Z [test] stdout: FAIL
+2018-04-17T08:03:20Z [test (run 2)] stdout: PASS
+`, buf.String())
+	})
+}
+
+func TestWriteGroupedText(t *testing.T) {
+	t.Run("ReturnsGroupForEveryStepNestedStepAndService", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buf bytes.Buffer
+
+		// act
+		err := WriteJobLogGroupedText(&buf, &buildLog)
+
+		assert.Nil(t, err)
+		assert.Equal(t, `::group::build
+go build ./...
+warning: deprecated flag
+::endgroup::
+::group::build/lint
+golint ./...
+::endgroup::
+::group::build/postgres (service)
+database system is ready to accept connections
+::endgroup::
+::group::test
+FAIL
+::endgroup::
+::error title=test::test failed with exit code 1
+::group::test (run 2)
+PASS
+::endgroup::
+`, buf.String())
+	})
+}
+
+func getExportBuildLog() BuildLog {
+	return BuildLog{
+		ID:           "5",
+		RepoSource:   "github.com",
+		RepoOwner:    "ziplineeci",
+		RepoName:     "ziplinee-ci-api",
+		RepoBranch:   "master",
+		RepoRevision: "as23456",
+		BuildID:      "15",
+		Steps: []*BuildLogStep{
+			&BuildLogStep{
+				Step:     "build",
+				Duration: 10 * time.Second,
+				LogLines: []BuildLogLine{
+					BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 1, 0, time.UTC), StreamType: "stdout", Text: "go build ./..."},
+					BuildLogLine{LineNumber: 2, Timestamp: time.Date(2018, 4, 17, 8, 3, 2, 0, time.UTC), StreamType: "stderr", Text: "warning: deprecated flag"},
+				},
+				Status: LogStatusSucceeded,
+				NestedSteps: []*BuildLogStep{
+					&BuildLogStep{
+						Step:     "lint",
+						Depth:    1,
+						Duration: 5 * time.Second,
+						LogLines: []BuildLogLine{
+							BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 1, 0, time.UTC), StreamType: "stdout", Text: "golint ./..."},
+						},
+						Status: LogStatusSucceeded,
+					},
+				},
+				Services: []*BuildLogStep{
+					&BuildLogStep{
+						Step:     "postgres",
+						Depth:    1,
+						Duration: 10 * time.Second,
+						LogLines: []BuildLogLine{
+							BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC), StreamType: "stdout", Text: "database system is ready to accept connections"},
+						},
+						Status: LogStatusSucceeded,
+					},
+				},
+			},
+			&BuildLogStep{
+				Step:     "test",
+				Duration: 7 * time.Second,
+				LogLines: []BuildLogLine{
+					BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 12, 0, time.UTC), StreamType: "stdout", Text: "FAIL"},
+				},
+				ExitCode: 1,
+				Status:   LogStatusFailed,
+			},
+			&BuildLogStep{
+				Step:     "test",
+				RunIndex: 1,
+				Duration: 8 * time.Second,
+				LogLines: []BuildLogLine{
+					BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 20, 0, time.UTC), StreamType: "stdout", Text: "PASS"},
+				},
+				Status: LogStatusSucceeded,
+			},
+		},
+		InsertedAt: time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC),
+	}
+}
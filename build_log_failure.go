@@ -0,0 +1,232 @@
+package contracts
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type FailureExcerptReason string
+
+const (
+	// FailureExcerptReasonStderr indicates the line is one of the last lines written to stderr
+	FailureExcerptReasonStderr FailureExcerptReason = "stderr"
+	// FailureExcerptReasonError indicates the line looks like an error message
+	FailureExcerptReasonError FailureExcerptReason = "error"
+	// FailureExcerptReasonStackTrace indicates the line is part of a go, java or python stack trace
+	FailureExcerptReasonStackTrace FailureExcerptReason = "stackTrace"
+	// FailureExcerptReasonImage indicates the line is the error from pulling the step's docker image
+	FailureExcerptReasonImage FailureExcerptReason = "image"
+)
+
+const (
+	defaultFailureExcerptMaxLines    = 20
+	defaultFailureExcerptStderrLines = 5
+	maxStackTraceLines               = 30
+)
+
+// FailureSummaryOptions controls how many lines are extracted per failed step; a value of 0 means the default is used
+type FailureSummaryOptions struct {
+	MaxLinesPerStep int `json:"maxLinesPerStep,omitempty"`
+	StderrLines     int `json:"stderrLines,omitempty"`
+}
+
+// FailureSummary contains the most likely root cause lines for all failed steps of a log
+type FailureSummary struct {
+	Status      LogStatus           `json:"status"`
+	FailedSteps []FailedStepSummary `json:"failedSteps,omitempty"`
+}
+
+// FailedStepSummary has the excerpt of the log lines of a single failed step
+type FailedStepSummary struct {
+	StepPath   string               `json:"stepPath"`
+	Type       LogType              `json:"type"`
+	RunIndex   int                  `json:"runIndex,omitempty"`
+	ExitCode   int64                `json:"exitCode"`
+	Status     LogStatus            `json:"status"`
+	ImageError string               `json:"imageError,omitempty"`
+	Excerpt    []FailureExcerptLine `json:"excerpt,omitempty"`
+}
+
+// FailureExcerptLine is a log line picked as likely root cause, with the reason it was picked
+type FailureExcerptLine struct {
+	BuildLogLine
+	Reason FailureExcerptReason `json:"reason"`
+}
+
+var (
+	failureErrorRegex = regexp.MustCompile(`(?i)\b(error|fatal|failed|failure|panic|exception|denied|unauthorized|not found)\b`)
+
+	stackTraceStartRegex = regexp.MustCompile(`^(panic: |goroutine \d+ \[|Exception in thread |Caused by: |Traceback \(most recent call last\):|[\w.$]+(Exception|Error)(: |$))`)
+	stackTraceFrameRegex = regexp.MustCompile(`^(\s+at [\w.$<>/]+\(.*\)|\s+\.\.\. \d+ more|\s+File ".*", line \d+|\t/.+\.go:\d+|[\w./*()-]+\(.*\)$|created by |\s{2,}\S|Caused by: |[\w.$]+(Exception|Error)(: |$)|\s*$)`)
+)
+
+// GetJobLogFailureSummary returns the root cause excerpts for the failed steps in the build, release or bot log
+func GetJobLogFailureSummary(log JobLog, options FailureSummaryOptions) FailureSummary {
+	return GetFailureSummary(log.GetSteps(), options)
+}
+
+// GetFailureSummary returns the root cause excerpts for all failed steps, nested steps and services; steps that
+// failed but succeeded in a later run are left out, just like they are for the aggregated status
+func GetFailureSummary(steps []*BuildLogStep, options FailureSummaryOptions) FailureSummary {
+
+	if options.MaxLinesPerStep <= 0 {
+		options.MaxLinesPerStep = defaultFailureExcerptMaxLines
+	}
+	if options.StderrLines <= 0 {
+		options.StderrLines = defaultFailureExcerptStderrLines
+	}
+
+	summary := FailureSummary{
+		Status: GetAggregatedStatus(steps),
+	}
+
+	// the last run of a step is leading
+	lastRunIndex := map[string]int{}
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		lastRunIndex[strings.Join(path, "/")] = step.RunIndex
+	})
+
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		stepPath := strings.Join(path, "/")
+		if !isFailedStep(step) || step.RunIndex != lastRunIndex[stepPath] {
+			return
+		}
+
+		stepSummary := FailedStepSummary{
+			StepPath: stepPath,
+			Type:     logType,
+			RunIndex: step.RunIndex,
+			ExitCode: step.ExitCode,
+			Status:   step.Status,
+			Excerpt:  getFailureExcerpt(step, options),
+		}
+		if step.Image != nil {
+			stepSummary.ImageError = step.Image.Error
+		}
+
+		summary.FailedSteps = append(summary.FailedSteps, stepSummary)
+	})
+
+	return summary
+}
+
+// GetDescription returns a single line description of the first failure, truncated to maxLength characters so it fits in a commit status
+func (summary FailureSummary) GetDescription(maxLength int) string {
+
+	if len(summary.FailedSteps) == 0 {
+		return ""
+	}
+
+	first := summary.FailedSteps[0]
+	description := fmt.Sprintf("%v failed with exit code %v", first.StepPath, first.ExitCode)
+
+	for _, l := range first.Excerpt {
+		if l.Reason == FailureExcerptReasonImage || l.Reason == FailureExcerptReasonError {
+			description = fmt.Sprintf("%v: %v", description, strings.TrimSpace(l.Text))
+			break
+		}
+	}
+
+	if len(summary.FailedSteps) > 1 {
+		description = fmt.Sprintf("%v (and %v more)", description, len(summary.FailedSteps)-1)
+	}
+
+	if maxLength > 3 && len([]rune(description)) > maxLength {
+		description = string([]rune(description)[:maxLength-3]) + "..."
+	}
+
+	return description
+}
+
+func isFailedStep(step *BuildLogStep) bool {
+	return step.Status == LogStatusFailed || step.ExitCode != 0 || (step.Image != nil && step.Image.Error != "")
+}
+
+func getFailureExcerpt(step *BuildLogStep, options FailureSummaryOptions) []FailureExcerptLine {
+
+	// lower priority wins when the excerpt needs to be cut down
+	priorities := map[FailureExcerptReason]int{
+		FailureExcerptReasonImage:      0,
+		FailureExcerptReasonStackTrace: 1,
+		FailureExcerptReasonError:      2,
+		FailureExcerptReasonStderr:     3,
+	}
+
+	reasons := map[int]FailureExcerptReason{}
+	pick := func(i int, reason FailureExcerptReason) {
+		if current, ok := reasons[i]; !ok || priorities[reason] < priorities[current] {
+			reasons[i] = reason
+		}
+	}
+
+	lines := step.LogLines
+
+	for i := 0; i < len(lines); i++ {
+		if stackTraceStartRegex.MatchString(lines[i].Text) {
+			pick(i, FailureExcerptReasonStackTrace)
+			for j := i + 1; j < len(lines) && j <= i+maxStackTraceLines && stackTraceFrameRegex.MatchString(lines[j].Text); j++ {
+				pick(j, FailureExcerptReasonStackTrace)
+				i = j
+			}
+			continue
+		}
+		if failureErrorRegex.MatchString(lines[i].Text) {
+			pick(i, FailureExcerptReasonError)
+		}
+	}
+
+	stderrLines := 0
+	for i := len(lines) - 1; i >= 0 && stderrLines < options.StderrLines; i-- {
+		if lines[i].StreamType == StreamTypeStderr {
+			pick(i, FailureExcerptReasonStderr)
+			stderrLines++
+		}
+	}
+
+	indices := make([]int, 0, len(reasons))
+	for i := range reasons {
+		indices = append(indices, i)
+	}
+
+	maxLines := options.MaxLinesPerStep
+	if step.Image != nil && step.Image.Error != "" {
+		maxLines--
+	}
+	if len(indices) > maxLines {
+		// keep the most important lines, and of equally important lines the last ones since they're closest to the failure
+		sort.Slice(indices, func(a, b int) bool {
+			pa, pb := priorities[reasons[indices[a]]], priorities[reasons[indices[b]]]
+			if pa != pb {
+				return pa < pb
+			}
+			return indices[a] > indices[b]
+		})
+		if maxLines < 0 {
+			maxLines = 0
+		}
+		indices = indices[:maxLines]
+	}
+	sort.Ints(indices)
+
+	excerpt := []FailureExcerptLine{}
+	if step.Image != nil && step.Image.Error != "" {
+		excerpt = append(excerpt, FailureExcerptLine{
+			BuildLogLine: BuildLogLine{StreamType: StreamTypeStderr, Text: step.Image.Error},
+			Reason:       FailureExcerptReasonImage,
+		})
+	}
+	for _, i := range indices {
+		line := lines[i]
+		if line.LineNumber == 0 {
+			line.LineNumber = i + 1
+		}
+		excerpt = append(excerpt, FailureExcerptLine{
+			BuildLogLine: line,
+			Reason:       reasons[i],
+		})
+	}
+
+	return excerpt
+}
@@ -0,0 +1,208 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFailureSummary(t *testing.T) {
+	t.Run("ReturnsNoFailedStepsForSucceededLog", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 3),
+		}
+
+		// act
+		summary := GetFailureSummary(steps, FailureSummaryOptions{})
+
+		assert.Equal(t, LogStatusSucceeded, summary.Status)
+		assert.Equal(t, 0, len(summary.FailedSteps))
+		assert.Equal(t, "", summary.GetDescription(140))
+	})
+
+	t.Run("SkipsStepsThatSucceededInRetry", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		summary := GetJobLogFailureSummary(&buildLog, FailureSummaryOptions{})
+
+		assert.Equal(t, LogStatusSucceeded, summary.Status)
+		assert.Equal(t, 0, len(summary.FailedSteps))
+	})
+
+	t.Run("ReturnsErrorLinesAndLastStderrLines", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:     "build",
+				ExitCode: 2,
+				Status:   LogStatusFailed,
+				LogLines: []BuildLogLine{
+					BuildLogLine{LineNumber: 1, StreamType: "stdout", Text: "go build ./..."},
+					BuildLogLine{LineNumber: 2, StreamType: "stdout", Text: "# github.com/ziplineeci/ziplinee-ci-contracts"},
+					BuildLogLine{LineNumber: 3, StreamType: "stdout", Text: "compiling"},
+					BuildLogLine{LineNumber: 4, StreamType: "stdout", Text: "./build.go:12:2: error: undefined: foo"},
+					BuildLogLine{LineNumber: 5, StreamType: "stderr", Text: "exit status 2"},
+				},
+			},
+		}
+
+		// act
+		summary := GetFailureSummary(steps, FailureSummaryOptions{})
+
+		assert.Equal(t, LogStatusFailed, summary.Status)
+		if assert.Equal(t, 1, len(summary.FailedSteps)) {
+			assert.Equal(t, "build", summary.FailedSteps[0].StepPath)
+			assert.Equal(t, int64(2), summary.FailedSteps[0].ExitCode)
+			if assert.Equal(t, 2, len(summary.FailedSteps[0].Excerpt)) {
+				assert.Equal(t, 4, summary.FailedSteps[0].Excerpt[0].LineNumber)
+				assert.Equal(t, FailureExcerptReasonError, summary.FailedSteps[0].Excerpt[0].Reason)
+				assert.Equal(t, 5, summary.FailedSteps[0].Excerpt[1].LineNumber)
+				assert.Equal(t, FailureExcerptReasonStderr, summary.FailedSteps[0].Excerpt[1].Reason)
+			}
+		}
+		assert.Equal(t, "build failed with exit code 2: ./build.go:12:2: error: undefined: foo", summary.GetDescription(140))
+		assert.Equal(t, "build failed with...", summary.GetDescription(20))
+	})
+
+	t.Run("ReturnsGoStackTrace", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:     "test",
+				ExitCode: 2,
+				Status:   LogStatusFailed,
+				LogLines: []BuildLogLine{
+					BuildLogLine{StreamType: "stdout", Text: "=== RUN   TestA"},
+					BuildLogLine{StreamType: "stderr", Text: "panic: runtime error: index out of range [1] with length 1"},
+					BuildLogLine{StreamType: "stderr", Text: ""},
+					BuildLogLine{StreamType: "stderr", Text: "goroutine 7 [running]:"},
+					BuildLogLine{StreamType: "stderr", Text: "github.com/ziplineeci/ziplinee-ci-contracts.TestA(0xc000102680)"},
+					BuildLogLine{StreamType: "stderr", Text: "\t/src/a_test.go:12 +0x1d"},
+					BuildLogLine{StreamType: "stdout", Text: "FAIL	github.com/ziplineeci/ziplinee-ci-contracts	0.012s"},
+				},
+			},
+		}
+
+		// act
+		summary := GetFailureSummary(steps, FailureSummaryOptions{})
+
+		excerpt := summary.FailedSteps[0].Excerpt
+		if assert.Equal(t, 5, len(excerpt)) {
+			assert.Equal(t, 2, excerpt[0].LineNumber)
+			assert.Equal(t, FailureExcerptReasonStackTrace, excerpt[0].Reason)
+			assert.Equal(t, "goroutine 7 [running]:", excerpt[2].Text)
+			assert.Equal(t, FailureExcerptReasonStackTrace, excerpt[2].Reason)
+			assert.Equal(t, "\t/src/a_test.go:12 +0x1d", excerpt[4].Text)
+			assert.Equal(t, FailureExcerptReasonStackTrace, excerpt[4].Reason)
+		}
+	})
+
+	t.Run("ReturnsJavaAndPythonStackTraces", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:     "java",
+				ExitCode: 1,
+				LogLines: []BuildLogLine{
+					BuildLogLine{Text: "starting"},
+					BuildLogLine{Text: "Exception in thread \"main\" java.lang.IllegalStateException: boom"},
+					BuildLogLine{Text: "\tat com.example.Main.run(Main.java:10)"},
+					BuildLogLine{Text: "\tat com.example.Main.main(Main.java:5)"},
+					BuildLogLine{Text: "done"},
+				},
+			},
+			&BuildLogStep{
+				Step:     "python",
+				ExitCode: 1,
+				LogLines: []BuildLogLine{
+					BuildLogLine{Text: "Traceback (most recent call last):"},
+					BuildLogLine{Text: "  File \"main.py\", line 3, in <module>"},
+					BuildLogLine{Text: "    raise ValueError(\"boom\")"},
+					BuildLogLine{Text: "ValueError: boom"},
+					BuildLogLine{Text: "done"},
+				},
+			},
+		}
+
+		// act
+		summary := GetFailureSummary(steps, FailureSummaryOptions{})
+
+		if assert.Equal(t, 2, len(summary.FailedSteps)) {
+			assert.Equal(t, 3, len(summary.FailedSteps[0].Excerpt))
+			assert.Equal(t, 4, len(summary.FailedSteps[1].Excerpt))
+			assert.Equal(t, "ValueError: boom", summary.FailedSteps[1].Excerpt[3].Text)
+		}
+		assert.Equal(t, "java failed with exit code 1 (and 1 more)", summary.GetDescription(140))
+	})
+
+	t.Run("ReturnsImageErrorForFailedPull", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:   "deploy",
+				Status: LogStatusFailed,
+				Image: &BuildLogStepDockerImage{
+					Name:  "extensions/gke",
+					Tag:   "dev",
+					Error: "manifest for extensions/gke:dev not found",
+				},
+			},
+		}
+
+		// act
+		summary := GetFailureSummary(steps, FailureSummaryOptions{})
+
+		if assert.Equal(t, 1, len(summary.FailedSteps)) {
+			assert.Equal(t, "manifest for extensions/gke:dev not found", summary.FailedSteps[0].ImageError)
+			if assert.Equal(t, 1, len(summary.FailedSteps[0].Excerpt)) {
+				assert.Equal(t, FailureExcerptReasonImage, summary.FailedSteps[0].Excerpt[0].Reason)
+			}
+		}
+	})
+
+	t.Run("LimitsExcerptToMaxLinesPreferringLastLines", func(t *testing.T) {
+
+		step := getTruncationStep("build", 10)
+		step.Status = LogStatusFailed
+		for i := range step.LogLines {
+			step.LogLines[i].Text = "error " + step.LogLines[i].Text
+		}
+
+		// act
+		summary := GetFailureSummary([]*BuildLogStep{step}, FailureSummaryOptions{MaxLinesPerStep: 3})
+
+		excerpt := summary.FailedSteps[0].Excerpt
+		if assert.Equal(t, 3, len(excerpt)) {
+			assert.Equal(t, 8, excerpt[0].LineNumber)
+			assert.Equal(t, 10, excerpt[2].LineNumber)
+		}
+	})
+
+	t.Run("IncludesFailedServicesAndNestedSteps", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:   "integration",
+				Status: LogStatusFailed,
+				NestedSteps: []*BuildLogStep{
+					&BuildLogStep{Step: "api", Status: LogStatusFailed, ExitCode: 1},
+				},
+				Services: []*BuildLogStep{
+					&BuildLogStep{Step: "postgres", Status: LogStatusFailed, ExitCode: 137},
+				},
+			},
+		}
+
+		// act
+		summary := GetFailureSummary(steps, FailureSummaryOptions{})
+
+		if assert.Equal(t, 3, len(summary.FailedSteps)) {
+			assert.Equal(t, "integration/api", summary.FailedSteps[1].StepPath)
+			assert.Equal(t, "integration/postgres", summary.FailedSteps[2].StepPath)
+			assert.Equal(t, LogTypeService, summary.FailedSteps[2].Type)
+		}
+	})
+}
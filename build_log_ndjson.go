@@ -0,0 +1,318 @@
+package contracts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LogRecordType indicates what a single record in the ndjson log format holds
+type LogRecordType string
+
+const (
+	// LogRecordTypeHeader holds the log without its steps and is always the first record
+	LogRecordTypeHeader LogRecordType = "header"
+	// LogRecordTypeStep holds a step, nested step or service without its log lines and children
+	LogRecordTypeStep LogRecordType = "step"
+	// LogRecordTypeLine holds a single log line of the last step record with the same path, type and run index
+	LogRecordTypeLine LogRecordType = "line"
+)
+
+// LogRecord is a single line in the ndjson log format; a log is written as a header record followed by a step record
+// for every step and a line record for every log line, in the same depth-first order as the steps are nested
+type LogRecord struct {
+	RecordType LogRecordType   `json:"record"`
+	Header     *JobLogEnvelope `json:"header,omitempty"`
+	// Path holds the names of the parent steps and the step itself, like [build lint]
+	Path     []string      `json:"path,omitempty"`
+	Type     LogType       `json:"type,omitempty"`
+	RunIndex int           `json:"runIndex,omitempty"`
+	Step     *BuildLogStep `json:"step,omitempty"`
+	Line     *BuildLogLine `json:"line,omitempty"`
+}
+
+// GetStepPath returns the path of the step the record belongs to, like build/lint
+func (record *LogRecord) GetStepPath() string {
+	return strings.Join(record.Path, "/")
+}
+
+// LogEncoder writes build, release or bot logs as newline delimited json records
+type LogEncoder struct {
+	encoder *json.Encoder
+}
+
+// NewLogEncoder returns a LogEncoder writing to w
+func NewLogEncoder(w io.Writer) *LogEncoder {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	return &LogEncoder{
+		encoder: encoder,
+	}
+}
+
+// Encode writes the header, steps and log lines of the log
+func (e *LogEncoder) Encode(log JobLog) (err error) {
+	if err = e.WriteHeader(log); err != nil {
+		return
+	}
+
+	walkBuildLogSteps(log.GetSteps(), func(path []string, step *BuildLogStep, logType LogType) {
+		if err != nil {
+			return
+		}
+		if err = e.WriteStep(path, logType, step); err != nil {
+			return
+		}
+		for _, l := range step.LogLines {
+			if err = e.WriteLine(path, logType, step.RunIndex, l); err != nil {
+				return
+			}
+		}
+	})
+
+	return
+}
+
+// WriteHeader writes the log without its steps; it needs to be written before any step or line
+func (e *LogEncoder) WriteHeader(log JobLog) error {
+	header, err := getJobLogWithoutSteps(log)
+	if err != nil {
+		return err
+	}
+
+	envelope := NewJobLogEnvelope(header)
+
+	return e.WriteRecord(&LogRecord{
+		RecordType: LogRecordTypeHeader,
+		Header:     &envelope,
+	})
+}
+
+// WriteStep writes the step without its log lines, nested steps and services; those are written as separate records
+func (e *LogEncoder) WriteStep(path []string, logType LogType, step *BuildLogStep) error {
+	return e.WriteRecord(&LogRecord{
+		RecordType: LogRecordTypeStep,
+		Path:       path,
+		Type:       logType,
+		RunIndex:   step.RunIndex,
+		Step:       getBuildLogStepWithoutChildren(step),
+	})
+}
+
+// WriteLine writes a single log line for the step with the path, type and run index
+func (e *LogEncoder) WriteLine(path []string, logType LogType, runIndex int, line BuildLogLine) error {
+	return e.WriteRecord(&LogRecord{
+		RecordType: LogRecordTypeLine,
+		Path:       path,
+		Type:       logType,
+		RunIndex:   runIndex,
+		Line:       &line,
+	})
+}
+
+// WriteRecord writes a record as is, which allows proxying records read by a LogDecoder without reconstructing the log
+func (e *LogEncoder) WriteRecord(record *LogRecord) error {
+	return e.encoder.Encode(record)
+}
+
+// LogDecoder reads newline delimited json records written by a LogEncoder one at a time
+type LogDecoder struct {
+	decoder *json.Decoder
+}
+
+// NewLogDecoder returns a LogDecoder reading from r
+func NewLogDecoder(r io.Reader) *LogDecoder {
+	return &LogDecoder{
+		decoder: json.NewDecoder(r),
+	}
+}
+
+// Next returns the next record or io.EOF when all records have been read
+func (d *LogDecoder) Next() (*LogRecord, error) {
+	var record LogRecord
+	if err := d.decoder.Decode(&record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// LogBuilder reconstructs a log from its records as they come in, so it can be inspected before all records are read
+type LogBuilder struct {
+	log   JobLog
+	steps *[]*BuildLogStep
+	// stack holds the last step record at every depth to attach nested steps and services to
+	stack []*BuildLogStep
+	// current holds the last step record per type, path and run index to attach log lines to
+	current map[string]*BuildLogStep
+}
+
+// NewLogBuilder returns an empty LogBuilder
+func NewLogBuilder() *LogBuilder {
+	return &LogBuilder{
+		current: map[string]*BuildLogStep{},
+	}
+}
+
+// Add adds a record to the log; the header record needs to be added first
+func (b *LogBuilder) Add(record *LogRecord) error {
+	switch record.RecordType {
+	case LogRecordTypeHeader:
+		return b.addHeader(record)
+	case LogRecordTypeStep:
+		return b.addStep(record)
+	case LogRecordTypeLine:
+		return b.addLine(record)
+	}
+
+	return fmt.Errorf("log record type %q is not supported", record.RecordType)
+}
+
+// Log returns the log reconstructed so far or nil if no header has been added yet
+func (b *LogBuilder) Log() JobLog {
+	return b.log
+}
+
+func (b *LogBuilder) addHeader(record *LogRecord) error {
+	if b.log != nil {
+		return errors.New("log already has a header record")
+	}
+	if record.Header == nil || record.Header.Log == nil {
+		return errors.New("header record has no header")
+	}
+
+	b.log = record.Header.Log
+	switch l := b.log.(type) {
+	case *BuildLog:
+		b.steps = &l.Steps
+	case *ReleaseLog:
+		b.steps = &l.Steps
+	case *BotLog:
+		b.steps = &l.Steps
+	}
+	*b.steps = []*BuildLogStep{}
+
+	return nil
+}
+
+func (b *LogBuilder) addStep(record *LogRecord) error {
+	if b.log == nil {
+		return errors.New("step record arrived before header record")
+	}
+	if record.Step == nil || len(record.Path) == 0 {
+		return fmt.Errorf("step record for %v has no step or path", record.GetStepPath())
+	}
+
+	depth := len(record.Path) - 1
+	if depth > len(b.stack) {
+		return fmt.Errorf("step record for %v arrived before its parent", record.GetStepPath())
+	}
+
+	step := *record.Step
+	step.LogLines = []BuildLogLine{}
+	step.NestedSteps = nil
+	step.Services = nil
+
+	if depth == 0 {
+		if record.Type == LogTypeService {
+			return fmt.Errorf("service %v needs to have a parent stage", record.GetStepPath())
+		}
+		*b.steps = append(*b.steps, &step)
+	} else {
+		parent := b.stack[depth-1]
+		if parent.Step != record.Path[depth-1] {
+			return fmt.Errorf("step record for %v arrived after step %v instead of its parent", record.GetStepPath(), parent.Step)
+		}
+		if record.Type == LogTypeService {
+			parent.Services = append(parent.Services, &step)
+		} else {
+			parent.NestedSteps = append(parent.NestedSteps, &step)
+		}
+	}
+
+	b.stack = append(b.stack[:depth], &step)
+	b.current[getLogRecordKey(record)] = &step
+
+	return nil
+}
+
+func (b *LogBuilder) addLine(record *LogRecord) error {
+	if b.log == nil {
+		return errors.New("line record arrived before header record")
+	}
+	if record.Line == nil {
+		return fmt.Errorf("line record for %v has no line", record.GetStepPath())
+	}
+
+	step, ok := b.current[getLogRecordKey(record)]
+	if !ok {
+		return fmt.Errorf("line record for %v arrived before its step record", record.GetStepPath())
+	}
+	step.LogLines = append(step.LogLines, *record.Line)
+
+	return nil
+}
+
+// DecodeLog reads all records from r and returns the reconstructed log
+func DecodeLog(r io.Reader) (JobLog, error) {
+	decoder := NewLogDecoder(r)
+	builder := NewLogBuilder()
+
+	for {
+		record, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = builder.Add(record); err != nil {
+			return nil, err
+		}
+	}
+
+	if builder.Log() == nil {
+		return nil, errors.New("log has no header record")
+	}
+
+	return builder.Log(), nil
+}
+
+func getLogRecordKey(record *LogRecord) string {
+	// json encoding the path keeps names containing a slash apart
+	path, _ := json.Marshal(record.Path)
+	return fmt.Sprintf("%v%v#%v", record.Type, string(path), record.RunIndex)
+}
+
+// getJobLogWithoutSteps returns a copy of the log with its steps left out
+func getJobLogWithoutSteps(log JobLog) (JobLog, error) {
+	switch l := log.(type) {
+	case *BuildLog:
+		header := *l
+		header.Steps = nil
+		return &header, nil
+	case *ReleaseLog:
+		header := *l
+		header.Steps = nil
+		return &header, nil
+	case *BotLog:
+		header := *l
+		header.Steps = nil
+		return &header, nil
+	}
+
+	return nil, fmt.Errorf("log of type %T is not supported for encoding", log)
+}
+
+// getBuildLogStepWithoutChildren returns a copy of the step with its log lines, nested steps and services left out
+func getBuildLogStepWithoutChildren(step *BuildLogStep) *BuildLogStep {
+	stepWithoutChildren := *step
+	stepWithoutChildren.LogLines = nil
+	stepWithoutChildren.NestedSteps = nil
+	stepWithoutChildren.Services = nil
+
+	return &stepWithoutChildren
+}
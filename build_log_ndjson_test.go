@@ -0,0 +1,167 @@
+package contracts
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogEncoder(t *testing.T) {
+	t.Run("WritesHeaderStepAndLineRecords", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buffer bytes.Buffer
+
+		// act
+		err := NewLogEncoder(&buffer).Encode(&buildLog)
+
+		assert.Nil(t, err)
+		records := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		// 1 header, 5 steps and 6 lines
+		assert.Equal(t, 12, len(records))
+		assert.True(t, strings.HasPrefix(records[0], `{"record":"header","header":{"jobType":"build","log":{"id":"5"`))
+		assert.Contains(t, records[0], `"steps":null`)
+		assert.True(t, strings.HasPrefix(records[4], `{"record":"step","path":["build","lint"],"type":"stage","step":{"step":"lint"`))
+		assert.Equal(t, `{"record":"line","path":["build","postgres"],"type":"service","line":{"line":1,"timestamp":"2018-04-17T08:03:00Z","streamType":"stdout","text":"database system is ready to accept connections"}}`, records[7])
+		assert.True(t, strings.HasPrefix(records[10], `{"record":"step","path":["test"],"type":"stage","runIndex":1,`))
+	})
+}
+
+func TestDecodeLog(t *testing.T) {
+	t.Run("RoundTripsBuildLog", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buffer bytes.Buffer
+		err := NewLogEncoder(&buffer).Encode(&buildLog)
+		assert.Nil(t, err)
+
+		// act
+		log, err := DecodeLog(&buffer)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &buildLog, log)
+	})
+
+	t.Run("RoundTripsReleaseAndBotLogs", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		releaseLog := &ReleaseLog{ID: "6", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", ReleaseID: "2", Steps: buildLog.Steps, InsertedAt: buildLog.InsertedAt}
+		botLog := &BotLog{ID: "7", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", BotID: "3", Steps: []*BuildLogStep{}}
+
+		for _, jobLog := range []JobLog{releaseLog, botLog} {
+			var buffer bytes.Buffer
+			err := NewLogEncoder(&buffer).Encode(jobLog)
+			assert.Nil(t, err)
+
+			// act
+			log, err := DecodeLog(&buffer)
+
+			assert.Nil(t, err)
+			assert.Equal(t, jobLog, log)
+		}
+	})
+
+	t.Run("KeepsNestedStepsOfRetriedStagesApart", func(t *testing.T) {
+
+		buildLog := &BuildLog{
+			BuildID: "1",
+			Steps: []*BuildLogStep{
+				&BuildLogStep{Step: "build", LogLines: []BuildLogLine{}, NestedSteps: []*BuildLogStep{&BuildLogStep{Step: "lint", Depth: 1, LogLines: []BuildLogLine{{Text: "first run"}}}}},
+				&BuildLogStep{Step: "build", RunIndex: 1, LogLines: []BuildLogLine{}, NestedSteps: []*BuildLogStep{&BuildLogStep{Step: "lint", Depth: 1, LogLines: []BuildLogLine{{Text: "second run"}}}}},
+			},
+		}
+		var buffer bytes.Buffer
+		err := NewLogEncoder(&buffer).Encode(buildLog)
+		assert.Nil(t, err)
+
+		// act
+		log, err := DecodeLog(&buffer)
+
+		assert.Nil(t, err)
+		assert.Equal(t, buildLog, log)
+	})
+
+	t.Run("ReturnsErrorWithoutHeader", func(t *testing.T) {
+
+		// act
+		_, err := DecodeLog(strings.NewReader(`{"record":"step","path":["build"],"type":"stage","step":{"step":"build"}}`))
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ReturnsErrorForStepWithoutParent", func(t *testing.T) {
+
+		data := `{"record":"header","header":{"jobType":"build","log":{"buildID":"1"}}}
+{"record":"step","path":["build","lint"],"type":"stage","step":{"step":"lint"}}`
+
+		// act
+		_, err := DecodeLog(strings.NewReader(data))
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ReturnsErrorForLineWithoutStep", func(t *testing.T) {
+
+		data := `{"record":"header","header":{"jobType":"build","log":{"buildID":"1"}}}
+{"record":"line","path":["build"],"type":"stage","line":{"text":"orphan"}}`
+
+		// act
+		_, err := DecodeLog(strings.NewReader(data))
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestLogBuilder(t *testing.T) {
+	t.Run("ReconstructsLogIncrementally", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var buffer bytes.Buffer
+		err := NewLogEncoder(&buffer).Encode(&buildLog)
+		assert.Nil(t, err)
+		decoder := NewLogDecoder(&buffer)
+		builder := NewLogBuilder()
+
+		// act
+		for i := 0; i < 3; i++ {
+			record, err := decoder.Next()
+			assert.Nil(t, err)
+			err = builder.Add(record)
+			assert.Nil(t, err)
+		}
+
+		log := builder.Log()
+		if assert.NotNil(t, log) && assert.Equal(t, 1, len(log.GetSteps())) {
+			assert.Equal(t, "build", log.GetSteps()[0].Step)
+			assert.Equal(t, 1, len(log.GetSteps()[0].LogLines))
+		}
+	})
+
+	t.Run("ProxiesRecordsWithoutReconstructing", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		var source, target bytes.Buffer
+		err := NewLogEncoder(&source).Encode(&buildLog)
+		assert.Nil(t, err)
+		expected := source.String()
+		decoder := NewLogDecoder(&source)
+		encoder := NewLogEncoder(&target)
+
+		// act
+		for {
+			record, err := decoder.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			assert.Nil(t, err)
+			err = encoder.WriteRecord(record)
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, expected, target.String())
+	})
+}
@@ -0,0 +1,207 @@
+package contracts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// StreamTypeStdout is the stream type for lines written to standard output
+	StreamTypeStdout = "stdout"
+	// StreamTypeStderr is the stream type for lines written to standard error
+	StreamTypeStderr = "stderr"
+)
+
+// OversizedLineMode defines how lines longer than the maximum line length are handled
+type OversizedLineMode string
+
+const (
+	// OversizedLineModeSplit splits an oversized line into multiple lines, each with its own line number
+	OversizedLineModeSplit OversizedLineMode = "split"
+	// OversizedLineModeWrap keeps an oversized line as a single line but inserts line breaks in its text
+	OversizedLineModeWrap OversizedLineMode = "wrap"
+)
+
+// LogNormalizationPolicy controls how log lines are normalized
+type LogNormalizationPolicy struct {
+	// MaxLineLength is the maximum number of bytes in the text of a line; a value of 0 means no limit
+	MaxLineLength int `json:"maxLineLength,omitempty"`
+	// OversizedLineMode defaults to OversizedLineModeSplit
+	OversizedLineMode OversizedLineMode `json:"oversizedLineMode,omitempty"`
+	// DefaultStreamType replaces stream types other than stdout and stderr; defaults to stdout
+	DefaultStreamType string `json:"defaultStreamType,omitempty"`
+}
+
+// LogNormalizationStats reports what was changed while normalizing log lines
+type LogNormalizationStats struct {
+	InputLines         int `json:"inputLines"`
+	OutputLines        int `json:"outputLines"`
+	RenumberedLines    int `json:"renumberedLines"`
+	OversizedLines     int `json:"oversizedLines"`
+	RepairedTimestamps int `json:"repairedTimestamps"`
+	InvalidStreamTypes int `json:"invalidStreamTypes"`
+}
+
+// LogNormalizer normalizes log lines while they're being streamed: it numbers lines per step and run, handles
+// oversized lines, keeps timestamps from going backwards and replaces invalid stream types
+type LogNormalizer struct {
+	policy LogNormalizationPolicy
+	steps  map[string]*stepNormalizationState
+	stats  LogNormalizationStats
+}
+
+type stepNormalizationState struct {
+	lineCount     int
+	lastTimestamp time.Time
+}
+
+// NewLogNormalizer returns a LogNormalizer for the policy
+func NewLogNormalizer(policy LogNormalizationPolicy) *LogNormalizer {
+	if policy.OversizedLineMode == "" {
+		policy.OversizedLineMode = OversizedLineModeSplit
+	}
+	if policy.DefaultStreamType == "" {
+		policy.DefaultStreamType = StreamTypeStdout
+	}
+
+	return &LogNormalizer{
+		policy: policy,
+		steps:  map[string]*stepNormalizationState{},
+	}
+}
+
+// AddLine normalizes a log line for the step identified by key (for example the step path and run index) and returns the resulting lines
+func (n *LogNormalizer) AddLine(key string, line BuildLogLine) []BuildLogLine {
+
+	state, ok := n.steps[key]
+	if !ok {
+		state = &stepNormalizationState{}
+		n.steps[key] = state
+	}
+
+	n.stats.InputLines++
+
+	if line.StreamType != StreamTypeStdout && line.StreamType != StreamTypeStderr {
+		n.stats.InvalidStreamTypes++
+		line.StreamType = n.policy.DefaultStreamType
+	}
+
+	// container restarts can make timestamps go backwards; keep them in order within the step
+	if state.lineCount > 0 && line.Timestamp.Before(state.lastTimestamp) {
+		n.stats.RepairedTimestamps++
+		line.Timestamp = state.lastTimestamp
+	}
+	state.lastTimestamp = line.Timestamp
+
+	texts := []string{line.Text}
+	if n.policy.MaxLineLength > 0 && len(line.Text) > n.policy.MaxLineLength {
+		n.stats.OversizedLines++
+		texts = splitLogLineText(line.Text, n.policy.MaxLineLength)
+		if n.policy.OversizedLineMode == OversizedLineModeWrap {
+			texts = []string{strings.Join(texts, "\n")}
+		}
+	}
+
+	lines := make([]BuildLogLine, 0, len(texts))
+	for _, t := range texts {
+		state.lineCount++
+
+		normalizedLine := line
+		normalizedLine.Text = t
+		normalizedLine.LineNumber = state.lineCount
+		if line.LineNumber != normalizedLine.LineNumber {
+			n.stats.RenumberedLines++
+		}
+
+		lines = append(lines, normalizedLine)
+	}
+	n.stats.OutputLines += len(lines)
+
+	return lines
+}
+
+// AddTailLogLine normalizes the log line of a tail log line and returns a tail log line for every resulting line;
+// tail log lines without a log line, like status updates, are returned as is
+func (n *LogNormalizer) AddTailLogLine(tailLogLine TailLogLine) []TailLogLine {
+	if tailLogLine.LogLine == nil {
+		return []TailLogLine{tailLogLine}
+	}
+
+	lines := n.AddLine(getTailLogLineKey(tailLogLine), *tailLogLine.LogLine)
+
+	tailLogLines := make([]TailLogLine, 0, len(lines))
+	for i := range lines {
+		normalizedTailLogLine := tailLogLine
+		normalizedTailLogLine.LogLine = &lines[i]
+		tailLogLines = append(tailLogLines, normalizedTailLogLine)
+	}
+
+	return tailLogLines
+}
+
+// Stats returns what was changed so far
+func (n *LogNormalizer) Stats() LogNormalizationStats {
+	return n.stats
+}
+
+// NormalizeJobLog applies the normalization policy to all steps of the build, release or bot log
+func NormalizeJobLog(log JobLog, policy LogNormalizationPolicy) LogNormalizationStats {
+	return NormalizeSteps(log.GetSteps(), policy)
+}
+
+// NormalizeSteps applies the normalization policy to the log lines of all steps, nested steps and services in place
+func NormalizeSteps(steps []*BuildLogStep, policy LogNormalizationPolicy) LogNormalizationStats {
+
+	normalizer := NewLogNormalizer(policy)
+
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		if len(step.LogLines) == 0 {
+			return
+		}
+
+		key := fmt.Sprintf("%v#%v#%v", logType, strings.Join(path, "/"), step.RunIndex)
+
+		lines := make([]BuildLogLine, 0, len(step.LogLines))
+		for _, l := range step.LogLines {
+			lines = append(lines, normalizer.AddLine(key, l)...)
+		}
+
+		step.LogLines = lines
+	})
+
+	return normalizer.Stats()
+}
+
+func getTailLogLineKey(tailLogLine TailLogLine) string {
+	stepPath := tailLogLine.Step
+	if tailLogLine.ParentStage != "" {
+		stepPath = tailLogLine.ParentStage + "/" + tailLogLine.Step
+	}
+	logType := tailLogLine.Type
+	if logType == "" {
+		logType = LogTypeStage
+	}
+
+	return fmt.Sprintf("%v#%v#%v", logType, stepPath, tailLogLine.RunIndex)
+}
+
+// splitLogLineText splits text in parts of at most maxLength bytes without splitting multi-byte characters
+func splitLogLineText(text string, maxLength int) []string {
+	parts := []string{}
+	for len(text) > maxLength {
+		end := maxLength
+		for end > 0 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		if end == 0 {
+			// a single character longer than the maximum length can't be split
+			_, end = utf8.DecodeRuneInString(text)
+		}
+		parts = append(parts, text[:end])
+		text = text[end:]
+	}
+
+	return append(parts, text)
+}
@@ -0,0 +1,137 @@
+package contracts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogNormalizer(t *testing.T) {
+	t.Run("NumbersLinesPerStepAndRun", func(t *testing.T) {
+
+		normalizer := NewLogNormalizer(LogNormalizationPolicy{})
+
+		// act
+		first := normalizer.AddLine("build#0", BuildLogLine{StreamType: StreamTypeStdout, Text: "a"})
+		second := normalizer.AddLine("build#0", BuildLogLine{LineNumber: 7, StreamType: StreamTypeStdout, Text: "b"})
+		retry := normalizer.AddLine("build#1", BuildLogLine{StreamType: StreamTypeStdout, Text: "c"})
+
+		assert.Equal(t, 1, first[0].LineNumber)
+		assert.Equal(t, 2, second[0].LineNumber)
+		assert.Equal(t, 1, retry[0].LineNumber)
+		assert.Equal(t, 3, normalizer.Stats().RenumberedLines)
+	})
+
+	t.Run("SplitsOversizedLines", func(t *testing.T) {
+
+		normalizer := NewLogNormalizer(LogNormalizationPolicy{MaxLineLength: 4})
+
+		// act
+		lines := normalizer.AddLine("build#0", BuildLogLine{LineNumber: 1, StreamType: StreamTypeStdout, Text: "abcdefghij"})
+
+		if assert.Equal(t, 3, len(lines)) {
+			assert.Equal(t, "abcd", lines[0].Text)
+			assert.Equal(t, "efgh", lines[1].Text)
+			assert.Equal(t, "ij", lines[2].Text)
+			assert.Equal(t, 3, lines[2].LineNumber)
+		}
+		stats := normalizer.Stats()
+		assert.Equal(t, 1, stats.InputLines)
+		assert.Equal(t, 3, stats.OutputLines)
+		assert.Equal(t, 1, stats.OversizedLines)
+	})
+
+	t.Run("WrapsOversizedLines", func(t *testing.T) {
+
+		normalizer := NewLogNormalizer(LogNormalizationPolicy{MaxLineLength: 4, OversizedLineMode: OversizedLineModeWrap})
+
+		// act
+		lines := normalizer.AddLine("build#0", BuildLogLine{StreamType: StreamTypeStdout, Text: "abcdefghij"})
+
+		if assert.Equal(t, 1, len(lines)) {
+			assert.Equal(t, "abcd\nefgh\nij", lines[0].Text)
+		}
+	})
+
+	t.Run("DoesNotSplitMultiByteCharacters", func(t *testing.T) {
+
+		normalizer := NewLogNormalizer(LogNormalizationPolicy{MaxLineLength: 4})
+
+		// act
+		lines := normalizer.AddLine("build#0", BuildLogLine{StreamType: StreamTypeStdout, Text: "aéééb"})
+
+		texts := []string{}
+		for _, l := range lines {
+			texts = append(texts, l.Text)
+		}
+		assert.Equal(t, []string{"aé", "éé", "b"}, texts)
+		assert.Equal(t, "aéééb", strings.Join(texts, ""))
+	})
+
+	t.Run("RepairsTimestampsGoingBackwards", func(t *testing.T) {
+
+		normalizer := NewLogNormalizer(LogNormalizationPolicy{})
+		normalizer.AddLine("build#0", BuildLogLine{Timestamp: time.Date(2018, 4, 17, 8, 3, 5, 0, time.UTC), StreamType: StreamTypeStdout, Text: "a"})
+
+		// act
+		lines := normalizer.AddLine("build#0", BuildLogLine{Timestamp: time.Date(2018, 4, 17, 8, 3, 1, 0, time.UTC), StreamType: StreamTypeStdout, Text: "b"})
+
+		assert.Equal(t, time.Date(2018, 4, 17, 8, 3, 5, 0, time.UTC), lines[0].Timestamp)
+		assert.Equal(t, 1, normalizer.Stats().RepairedTimestamps)
+	})
+
+	t.Run("ReplacesInvalidStreamTypes", func(t *testing.T) {
+
+		normalizer := NewLogNormalizer(LogNormalizationPolicy{DefaultStreamType: StreamTypeStderr})
+
+		// act
+		lines := normalizer.AddLine("build#0", BuildLogLine{StreamType: "STDOUT", Text: "a"})
+
+		assert.Equal(t, StreamTypeStderr, lines[0].StreamType)
+		assert.Equal(t, 1, normalizer.Stats().InvalidStreamTypes)
+	})
+
+	t.Run("NormalizesTailLogLinesPerStep", func(t *testing.T) {
+
+		normalizer := NewLogNormalizer(LogNormalizationPolicy{MaxLineLength: 2})
+		status := LogStatusRunning
+
+		// act
+		nested := normalizer.AddTailLogLine(TailLogLine{Step: "lint", ParentStage: "build", Depth: 1, LogLine: &BuildLogLine{StreamType: StreamTypeStdout, Text: "abc"}})
+		service := normalizer.AddTailLogLine(TailLogLine{Step: "lint", ParentStage: "build", Type: LogTypeService, LogLine: &BuildLogLine{StreamType: StreamTypeStdout, Text: "x"}})
+		statusUpdate := normalizer.AddTailLogLine(TailLogLine{Step: "lint", ParentStage: "build", Status: &status})
+
+		if assert.Equal(t, 2, len(nested)) {
+			assert.Equal(t, "lint", nested[1].Step)
+			assert.Equal(t, 1, nested[1].Depth)
+			assert.Equal(t, "c", nested[1].LogLine.Text)
+			assert.Equal(t, 2, nested[1].LogLine.LineNumber)
+		}
+		assert.Equal(t, 1, service[0].LogLine.LineNumber)
+		assert.Equal(t, []TailLogLine{TailLogLine{Step: "lint", ParentStage: "build", Status: &status}}, statusUpdate)
+	})
+}
+
+func TestNormalizeSteps(t *testing.T) {
+	t.Run("NormalizesAllStepsInPlace", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+		buildLog.Steps[0].LogLines[1].StreamType = ""
+		buildLog.Steps[0].LogLines[1].LineNumber = 0
+
+		// act
+		stats := NormalizeJobLog(&buildLog, LogNormalizationPolicy{MaxLineLength: 20})
+
+		assert.Equal(t, 6, stats.InputLines)
+		assert.Equal(t, 9, stats.OutputLines)
+		assert.Equal(t, 1, stats.InvalidStreamTypes)
+		assert.Equal(t, []BuildLogLine{
+			BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 1, 0, time.UTC), StreamType: "stdout", Text: "go build ./..."},
+			BuildLogLine{LineNumber: 2, Timestamp: time.Date(2018, 4, 17, 8, 3, 2, 0, time.UTC), StreamType: "stdout", Text: "warning: deprecated "},
+			BuildLogLine{LineNumber: 3, Timestamp: time.Date(2018, 4, 17, 8, 3, 2, 0, time.UTC), StreamType: "stdout", Text: "flag"},
+		}, buildLog.Steps[0].LogLines)
+		assert.Equal(t, 3, len(buildLog.Steps[0].Services[0].LogLines))
+	})
+}
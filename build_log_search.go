@@ -0,0 +1,134 @@
+package contracts
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogSearchQuery defines what to search for in a build, release or bot log
+type LogSearchQuery struct {
+	// Text is matched literally; either Text or Regex needs to be set
+	Text string `json:"text,omitempty"`
+	// Regex is a regular expression in RE2 syntax
+	Regex         string `json:"regex,omitempty"`
+	CaseSensitive bool   `json:"caseSensitive,omitempty"`
+	ContextLines  int    `json:"contextLines,omitempty"`
+	// StreamType limits the search to stdout or stderr lines
+	StreamType string `json:"streamType,omitempty"`
+	// StepPath limits the search to a step and all its nested steps and services, like build/lint
+	StepPath string `json:"stepPath,omitempty"`
+	MaxHits  int    `json:"maxHits,omitempty"`
+}
+
+// LogSearchHit is a single line matching a LogSearchQuery
+type LogSearchHit struct {
+	StepPath      string           `json:"stepPath"`
+	Type          LogType          `json:"type"`
+	RunIndex      int              `json:"runIndex,omitempty"`
+	LineNumber    int              `json:"line"`
+	Timestamp     time.Time        `json:"timestamp"`
+	StreamType    string           `json:"streamType"`
+	Text          string           `json:"text"`
+	Matches       []LogSearchMatch `json:"matches"`
+	ContextBefore []BuildLogLine   `json:"contextBefore,omitempty"`
+	ContextAfter  []BuildLogLine   `json:"contextAfter,omitempty"`
+}
+
+// LogSearchMatch is the byte range of a match within the text of a log line
+type LogSearchMatch struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// SearchJobLog returns all lines in the build, release or bot log matching the query
+func SearchJobLog(log JobLog, query LogSearchQuery) ([]LogSearchHit, error) {
+	return SearchSteps(log.GetSteps(), query)
+}
+
+// SearchSteps returns all lines in the steps, nested steps and services matching the query
+func SearchSteps(steps []*BuildLogStep, query LogSearchQuery) ([]LogSearchHit, error) {
+
+	re, err := query.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	hits := []LogSearchHit{}
+
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		if query.MaxHits > 0 && len(hits) >= query.MaxHits {
+			return
+		}
+
+		stepPath := strings.Join(path, "/")
+		if query.StepPath != "" && stepPath != query.StepPath && !strings.HasPrefix(stepPath, query.StepPath+"/") {
+			return
+		}
+
+		for i, l := range step.LogLines {
+			if query.StreamType != "" && l.StreamType != query.StreamType {
+				continue
+			}
+
+			indices := re.FindAllStringIndex(l.Text, -1)
+			if len(indices) == 0 {
+				continue
+			}
+
+			hit := LogSearchHit{
+				StepPath:   stepPath,
+				Type:       logType,
+				RunIndex:   step.RunIndex,
+				LineNumber: l.LineNumber,
+				Timestamp:  l.Timestamp,
+				StreamType: l.StreamType,
+				Text:       l.Text,
+				Matches:    make([]LogSearchMatch, 0, len(indices)),
+			}
+			if hit.LineNumber == 0 {
+				hit.LineNumber = i + 1
+			}
+			for _, m := range indices {
+				hit.Matches = append(hit.Matches, LogSearchMatch{Start: m[0], End: m[1]})
+			}
+
+			if query.ContextLines > 0 {
+				from := i - query.ContextLines
+				if from < 0 {
+					from = 0
+				}
+				to := i + 1 + query.ContextLines
+				if to > len(step.LogLines) {
+					to = len(step.LogLines)
+				}
+				hit.ContextBefore = append([]BuildLogLine{}, step.LogLines[from:i]...)
+				hit.ContextAfter = append([]BuildLogLine{}, step.LogLines[i+1:to]...)
+			}
+
+			hits = append(hits, hit)
+			if query.MaxHits > 0 && len(hits) >= query.MaxHits {
+				return
+			}
+		}
+	})
+
+	return hits, nil
+}
+
+func (query LogSearchQuery) compile() (*regexp.Regexp, error) {
+
+	pattern := query.Regex
+	if pattern == "" {
+		if query.Text == "" {
+			return nil, errors.New("either text or regex needs to be set to search logs")
+		}
+		pattern = regexp.QuoteMeta(query.Text)
+	}
+	if !query.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	return regexp.Compile(pattern)
+}
@@ -0,0 +1,147 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchSteps(t *testing.T) {
+	t.Run("ReturnsHitsAcrossStepsNestedStepsAndServices", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		hits, err := SearchJobLog(&buildLog, LogSearchQuery{Text: "GO"})
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 2, len(hits)) {
+			assert.Equal(t, "build", hits[0].StepPath)
+			assert.Equal(t, LogTypeStage, hits[0].Type)
+			assert.Equal(t, 1, hits[0].LineNumber)
+			assert.Equal(t, []LogSearchMatch{{Start: 0, End: 2}}, hits[0].Matches)
+			assert.Equal(t, "build/lint", hits[1].StepPath)
+			assert.Equal(t, []LogSearchMatch{{Start: 0, End: 2}}, hits[1].Matches)
+		}
+	})
+
+	t.Run("RespectsCaseSensitivity", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		hits, err := SearchJobLog(&buildLog, LogSearchQuery{Text: "GO", CaseSensitive: true})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(hits))
+	})
+
+	t.Run("ReturnsHitsForRegex", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		hits, err := SearchJobLog(&buildLog, LogSearchQuery{Regex: "^(PASS|FAIL)$"})
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 2, len(hits)) {
+			assert.Equal(t, "test", hits[0].StepPath)
+			assert.Equal(t, 0, hits[0].RunIndex)
+			assert.Equal(t, "test", hits[1].StepPath)
+			assert.Equal(t, 1, hits[1].RunIndex)
+		}
+	})
+
+	t.Run("ReturnsServiceHits", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		hits, err := SearchJobLog(&buildLog, LogSearchQuery{Text: "ready to accept"})
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(hits)) {
+			assert.Equal(t, "build/postgres", hits[0].StepPath)
+			assert.Equal(t, LogTypeService, hits[0].Type)
+		}
+	})
+
+	t.Run("ReturnsContextLines", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 10),
+		}
+
+		// act
+		hits, err := SearchSteps(steps, LogSearchQuery{Text: "line 5", ContextLines: 2})
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(hits)) {
+			assert.Equal(t, 5, hits[0].LineNumber)
+			if assert.Equal(t, 2, len(hits[0].ContextBefore)) {
+				assert.Equal(t, "line 3", hits[0].ContextBefore[0].Text)
+				assert.Equal(t, "line 4", hits[0].ContextBefore[1].Text)
+			}
+			if assert.Equal(t, 2, len(hits[0].ContextAfter)) {
+				assert.Equal(t, "line 6", hits[0].ContextAfter[0].Text)
+				assert.Equal(t, "line 7", hits[0].ContextAfter[1].Text)
+			}
+		}
+	})
+
+	t.Run("ClipsContextLinesAtStepBoundaries", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 3),
+		}
+
+		// act
+		hits, err := SearchSteps(steps, LogSearchQuery{Text: "line 1", ContextLines: 5})
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(hits)) {
+			assert.Equal(t, 0, len(hits[0].ContextBefore))
+			assert.Equal(t, 2, len(hits[0].ContextAfter))
+		}
+	})
+
+	t.Run("FiltersByStreamTypeAndStepPath", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		stderrHits, err := SearchJobLog(&buildLog, LogSearchQuery{Regex: ".", StreamType: "stderr"})
+		assert.Nil(t, err)
+		stepHits, err := SearchJobLog(&buildLog, LogSearchQuery{Regex: ".", StepPath: "build"})
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, len(stderrHits))
+		assert.Equal(t, 4, len(stepHits))
+	})
+
+	t.Run("StopsAfterMaxHits", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 10),
+			getTruncationStep("test", 10),
+		}
+
+		// act
+		hits, err := SearchSteps(steps, LogSearchQuery{Text: "line", MaxHits: 3})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(hits))
+	})
+
+	t.Run("ReturnsErrorForInvalidQuery", func(t *testing.T) {
+
+		steps := []*BuildLogStep{}
+
+		// act
+		_, errEmpty := SearchSteps(steps, LogSearchQuery{})
+		_, errRegex := SearchSteps(steps, LogSearchQuery{Regex: "("})
+
+		assert.NotNil(t, errEmpty)
+		assert.NotNil(t, errRegex)
+	})
+}
@@ -0,0 +1,104 @@
+package contracts
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogTimelineFilter limits the entries returned in a timeline
+type LogTimelineFilter struct {
+	// From includes lines at or after this time; the zero value doesn't limit the start
+	From time.Time `json:"from,omitempty"`
+	// To includes lines before this time; the zero value doesn't limit the end
+	To time.Time `json:"to,omitempty"`
+	// StepPaths limits the timeline to these steps and all their nested steps and services, like build/lint
+	StepPaths []string `json:"stepPaths,omitempty"`
+}
+
+// LogTimelineEntry is a single log line in a timeline merging the lines of all stages and services
+type LogTimelineEntry struct {
+	StepPath string  `json:"stepPath"`
+	Type     LogType `json:"type"`
+	// ServiceName is set for lines logged by a service
+	ServiceName string    `json:"serviceName,omitempty"`
+	RunIndex    int       `json:"runIndex,omitempty"`
+	LineNumber  int       `json:"line"`
+	Timestamp   time.Time `json:"timestamp"`
+	StreamType  string    `json:"streamType"`
+	Text        string    `json:"text"`
+}
+
+// GetJobLogTimeline returns the lines of all stages and services in the build, release or bot log ordered by time
+func GetJobLogTimeline(log JobLog, filter LogTimelineFilter) []LogTimelineEntry {
+	return GetTimeline(log.GetSteps(), filter)
+}
+
+// GetTimeline flattens the steps, nested steps and services into a single list of lines ordered by time; lines with
+// the same timestamp keep the order in which they appear in the log
+func GetTimeline(steps []*BuildLogStep, filter LogTimelineFilter) []LogTimelineEntry {
+
+	entries := []LogTimelineEntry{}
+
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		stepPath := strings.Join(path, "/")
+		if !filter.includesStep(stepPath) {
+			return
+		}
+
+		for i, l := range step.LogLines {
+			if !filter.includesTime(l.Timestamp) {
+				continue
+			}
+
+			entry := LogTimelineEntry{
+				StepPath:   stepPath,
+				Type:       logType,
+				RunIndex:   step.RunIndex,
+				LineNumber: l.LineNumber,
+				Timestamp:  l.Timestamp,
+				StreamType: l.StreamType,
+				Text:       l.Text,
+			}
+			if entry.LineNumber == 0 {
+				entry.LineNumber = i + 1
+			}
+			if logType == LogTypeService {
+				entry.ServiceName = step.Step
+			}
+
+			entries = append(entries, entry)
+		}
+	})
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	return entries
+}
+
+func (filter LogTimelineFilter) includesStep(stepPath string) bool {
+	if len(filter.StepPaths) == 0 {
+		return true
+	}
+
+	for _, p := range filter.StepPaths {
+		if stepPath == p || strings.HasPrefix(stepPath, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (filter LogTimelineFilter) includesTime(timestamp time.Time) bool {
+	if !filter.From.IsZero() && timestamp.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !timestamp.Before(filter.To) {
+		return false
+	}
+
+	return true
+}
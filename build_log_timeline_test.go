@@ -0,0 +1,87 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTimeline(t *testing.T) {
+	t.Run("MergesStagesAndServicesByTime", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		timeline := GetJobLogTimeline(&buildLog, LogTimelineFilter{})
+
+		if assert.Equal(t, 6, len(timeline)) {
+			assert.Equal(t, "build/postgres", timeline[0].StepPath)
+			assert.Equal(t, LogTypeService, timeline[0].Type)
+			assert.Equal(t, "postgres", timeline[0].ServiceName)
+			// build and lint both log at 08:03:01 and keep their order in the log
+			assert.Equal(t, "go build ./...", timeline[1].Text)
+			assert.Equal(t, "golint ./...", timeline[2].Text)
+			assert.Equal(t, "build/lint", timeline[2].StepPath)
+			assert.Equal(t, "", timeline[2].ServiceName)
+			assert.Equal(t, "warning: deprecated flag", timeline[3].Text)
+			assert.Equal(t, "stderr", timeline[3].StreamType)
+			assert.Equal(t, "FAIL", timeline[4].Text)
+			assert.Equal(t, 0, timeline[4].RunIndex)
+			assert.Equal(t, "PASS", timeline[5].Text)
+			assert.Equal(t, 1, timeline[5].RunIndex)
+		}
+	})
+
+	t.Run("FiltersByTimeWindow", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		timeline := GetJobLogTimeline(&buildLog, LogTimelineFilter{
+			From: time.Date(2018, 4, 17, 8, 3, 1, 0, time.UTC),
+			To:   time.Date(2018, 4, 17, 8, 3, 12, 0, time.UTC),
+		})
+
+		if assert.Equal(t, 3, len(timeline)) {
+			assert.Equal(t, "go build ./...", timeline[0].Text)
+			assert.Equal(t, "warning: deprecated flag", timeline[2].Text)
+		}
+	})
+
+	t.Run("FiltersByStepPaths", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		timeline := GetJobLogTimeline(&buildLog, LogTimelineFilter{
+			StepPaths: []string{"build/postgres", "test"},
+		})
+
+		if assert.Equal(t, 3, len(timeline)) {
+			assert.Equal(t, "build/postgres", timeline[0].StepPath)
+			assert.Equal(t, "test", timeline[1].StepPath)
+			assert.Equal(t, "test", timeline[2].StepPath)
+		}
+	})
+
+	t.Run("IncludesNestedStepsAndServicesOfStepPath", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		timeline := GetJobLogTimeline(&buildLog, LogTimelineFilter{
+			StepPaths: []string{"build"},
+		})
+
+		assert.Equal(t, 4, len(timeline))
+	})
+
+	t.Run("ReturnsEmptyTimelineForNoSteps", func(t *testing.T) {
+
+		// act
+		timeline := GetTimeline(nil, LogTimelineFilter{})
+
+		assert.Equal(t, []LogTimelineEntry{}, timeline)
+	})
+}
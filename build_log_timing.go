@@ -0,0 +1,161 @@
+package contracts
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimingReport contains the timing analysis of a build, release or bot log; the entries can be rendered as a waterfall chart.
+// CriticalPath holds the runs on the critical path by step path and run index, like test#1, see TimingEntry.RunPath
+type TimingReport struct {
+	TotalDuration        time.Duration `json:"totalDuration"`
+	CriticalPath         []string      `json:"criticalPath"`
+	CriticalPathDuration time.Duration `json:"criticalPathDuration"`
+	StagesDuration       time.Duration `json:"stagesDuration"`
+	ServicesDuration     time.Duration `json:"servicesDuration"`
+	ImagePullDuration    time.Duration `json:"imagePullDuration"`
+	ImagePullSize        int64         `json:"imagePullSize"`
+	ImagesPulled         int           `json:"imagesPulled"`
+	RetriesDuration      time.Duration `json:"retriesDuration"`
+	Retries              int           `json:"retries"`
+	Entries              []TimingEntry `json:"entries"`
+}
+
+// TimingEntry is the timing of a single step run, nested step or service relative to the start of the job
+type TimingEntry struct {
+	StepPath       string        `json:"stepPath"`
+	Type           LogType       `json:"type"`
+	RunIndex       int           `json:"runIndex,omitempty"`
+	Depth          int           `json:"depth,omitempty"`
+	Status         LogStatus     `json:"status"`
+	Offset         time.Duration `json:"offset"`
+	PullDuration   time.Duration `json:"pullDuration"`
+	Duration       time.Duration `json:"duration"`
+	OnCriticalPath bool          `json:"onCriticalPath,omitempty"`
+	Retried        bool          `json:"retried,omitempty"`
+}
+
+// End returns the offset at which the entry finished
+func (entry TimingEntry) End() time.Duration {
+	return entry.Offset + entry.PullDuration + entry.Duration
+}
+
+// RunPath returns the step path with the run index, like test#1, so retried runs of a step can be told apart
+func (entry TimingEntry) RunPath() string {
+	return fmt.Sprintf("%v#%v", entry.StepPath, entry.RunIndex)
+}
+
+// GetJobLogTimingReport returns the timing analysis for the build, release or bot log
+func GetJobLogTimingReport(log JobLog) TimingReport {
+	return GetTimingReport(log.GetSteps())
+}
+
+// GetTimingReport returns the timing analysis for the steps; top level stages run sequentially, each pulling its
+// image before running, while nested (parallel) stages and services start together with their parent stage
+func GetTimingReport(steps []*BuildLogStep) TimingReport {
+
+	report := TimingReport{
+		CriticalPath: []string{},
+		Entries:      []TimingEntry{},
+	}
+
+	// a run is retried if a later run exists for the same step
+	lastRunIndex := map[string]int{}
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		lastRunIndex[strings.Join(path, "/")] = step.RunIndex
+	})
+
+	var offset time.Duration
+	for _, s := range steps {
+		if s == nil {
+			continue
+		}
+
+		end, criticalPath := report.addStep([]string{s.Step}, s, LogTypeStage, offset, lastRunIndex)
+		for _, i := range criticalPath {
+			report.Entries[i].OnCriticalPath = true
+			report.CriticalPath = append(report.CriticalPath, report.Entries[i].RunPath())
+		}
+		report.StagesDuration += s.Duration
+		offset = end
+	}
+
+	report.TotalDuration = offset
+	report.CriticalPathDuration = offset
+
+	return report
+}
+
+// addStep adds entries for the step and everything running inside it and returns when it ends and the indices of the entries on its critical path
+func (report *TimingReport) addStep(path []string, step *BuildLogStep, logType LogType, offset time.Duration, lastRunIndex map[string]int) (time.Duration, []int) {
+
+	entry := report.addEntry(path, step, logType, offset, lastRunIndex)
+	runStart := offset + entry.PullDuration
+
+	end := entry.End()
+	criticalPath := []int{len(report.Entries) - 1}
+
+	// the longest nested stage determines how long the parent keeps the critical path busy
+	var longestNestedPath []int
+	var longestNestedEnd time.Duration
+	for _, ns := range step.NestedSteps {
+		if ns == nil {
+			continue
+		}
+		nestedEnd, nestedPath := report.addStep(append(path[:len(path):len(path)], ns.Step), ns, LogTypeStage, runStart, lastRunIndex)
+		if longestNestedPath == nil || nestedEnd > longestNestedEnd {
+			longestNestedEnd = nestedEnd
+			longestNestedPath = nestedPath
+		}
+	}
+	if longestNestedPath != nil {
+		criticalPath = append(criticalPath, longestNestedPath...)
+		if longestNestedEnd > end {
+			end = longestNestedEnd
+		}
+	}
+
+	for _, svc := range step.Services {
+		if svc == nil {
+			continue
+		}
+		report.ServicesDuration += svc.Duration
+		report.addStep(append(path[:len(path):len(path)], svc.Step), svc, LogTypeService, runStart, lastRunIndex)
+	}
+
+	return end, criticalPath
+}
+
+func (report *TimingReport) addEntry(path []string, step *BuildLogStep, logType LogType, offset time.Duration, lastRunIndex map[string]int) TimingEntry {
+
+	stepPath := strings.Join(path, "/")
+	entry := TimingEntry{
+		StepPath: stepPath,
+		Type:     logType,
+		RunIndex: step.RunIndex,
+		Depth:    step.Depth,
+		Status:   step.Status,
+		Offset:   offset,
+		Duration: step.Duration,
+		Retried:  step.RunIndex < lastRunIndex[stepPath],
+	}
+
+	if step.Image != nil {
+		entry.PullDuration = step.Image.PullDuration
+		if step.Image.IsPulled {
+			report.ImagePullDuration += step.Image.PullDuration
+			report.ImagePullSize += step.Image.ImageSize
+			report.ImagesPulled++
+		}
+	}
+
+	if entry.Retried {
+		report.Retries++
+		report.RetriesDuration += entry.PullDuration + entry.Duration
+	}
+
+	report.Entries = append(report.Entries, entry)
+
+	return entry
+}
@@ -0,0 +1,91 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTimingReport(t *testing.T) {
+	t.Run("ReturnsSequentialStagesWithRetries", func(t *testing.T) {
+
+		buildLog := getExportBuildLog()
+
+		// act
+		report := GetJobLogTimingReport(&buildLog)
+
+		assert.Equal(t, 25*time.Second, report.TotalDuration)
+		assert.Equal(t, 25*time.Second, report.CriticalPathDuration)
+		assert.Equal(t, []string{"build#0", "build/lint#0", "test#0", "test#1"}, report.CriticalPath)
+		assert.Equal(t, 25*time.Second, report.StagesDuration)
+		assert.Equal(t, 10*time.Second, report.ServicesDuration)
+		assert.Equal(t, 1, report.Retries)
+		assert.Equal(t, 7*time.Second, report.RetriesDuration)
+		if assert.Equal(t, 5, len(report.Entries)) {
+			assert.Equal(t, time.Duration(0), report.Entries[1].Offset)
+			assert.False(t, report.Entries[2].OnCriticalPath)
+			assert.Equal(t, LogTypeService, report.Entries[2].Type)
+			assert.Equal(t, 10*time.Second, report.Entries[3].Offset)
+			assert.True(t, report.Entries[3].Retried)
+			assert.Equal(t, 17*time.Second, report.Entries[4].Offset)
+			assert.Equal(t, 25*time.Second, report.Entries[4].End())
+			assert.False(t, report.Entries[4].Retried)
+		}
+	})
+
+	t.Run("IncludesImagePullsAndLongestParallelStage", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step:     "prepare",
+				Image:    &BuildLogStepDockerImage{Name: "alpine", Tag: "3.20", IsPulled: true, ImageSize: 3000, PullDuration: 2 * time.Second},
+				Duration: 3 * time.Second,
+			},
+			&BuildLogStep{
+				Step:     "parallel",
+				Duration: 4 * time.Second,
+				NestedSteps: []*BuildLogStep{
+					&BuildLogStep{
+						Step:     "unit",
+						Depth:    1,
+						Image:    &BuildLogStepDockerImage{Name: "golang", Tag: "1.22", IsPulled: true, ImageSize: 250000, PullDuration: 5 * time.Second},
+						Duration: 6 * time.Second,
+					},
+					&BuildLogStep{
+						Step:     "lint",
+						Depth:    1,
+						Image:    &BuildLogStepDockerImage{Name: "golangci-lint", Tag: "1.59", IsPulled: false, PullDuration: 10 * time.Millisecond},
+						Duration: 8 * time.Second,
+					},
+				},
+			},
+		}
+
+		// act
+		report := GetTimingReport(steps)
+
+		assert.Equal(t, []string{"prepare#0", "parallel#0", "parallel/unit#0"}, report.CriticalPath)
+		assert.Equal(t, 16*time.Second, report.TotalDuration)
+		assert.Equal(t, 7*time.Second, report.ImagePullDuration)
+		assert.Equal(t, int64(253000), report.ImagePullSize)
+		assert.Equal(t, 2, report.ImagesPulled)
+		assert.Equal(t, 0, report.Retries)
+		if assert.Equal(t, 4, len(report.Entries)) {
+			assert.Equal(t, 5*time.Second, report.Entries[1].Offset)
+			assert.Equal(t, 5*time.Second, report.Entries[2].Offset)
+			assert.True(t, report.Entries[2].OnCriticalPath)
+			assert.False(t, report.Entries[3].OnCriticalPath)
+		}
+	})
+
+	t.Run("ReturnsEmptyReportForNoSteps", func(t *testing.T) {
+
+		// act
+		report := GetTimingReport([]*BuildLogStep{})
+
+		assert.Equal(t, time.Duration(0), report.TotalDuration)
+		assert.Equal(t, 0, len(report.CriticalPath))
+		assert.Equal(t, 0, len(report.Entries))
+	})
+}
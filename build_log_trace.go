@@ -0,0 +1,274 @@
+package contracts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTraceServiceName is used as service.name resource attribute if no service name is set
+	DefaultTraceServiceName = "ziplinee-ci"
+
+	otlpScopeName = "github.com/ziplineeci/ziplinee-ci-contracts"
+
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOk     = 1
+	otlpStatusCodeError  = 2
+)
+
+// TraceExportOptions controls how a log is converted into spans
+type TraceExportOptions struct {
+	// ServiceName is set as service.name resource attribute; defaults to DefaultTraceServiceName
+	ServiceName string `json:"serviceName,omitempty"`
+	// StartTime is the time at which the job started; defaults to the time the log was inserted minus the total duration of the job
+	StartTime time.Time `json:"startTime,omitempty"`
+}
+
+// OTLPTraceData is the root of the OTLP json encoding of traces, as accepted by the /v1/traces endpoint of an OpenTelemetry collector
+type OTLPTraceData struct {
+	ResourceSpans []OTLPResourceSpans `json:"resourceSpans"`
+}
+
+// OTLPResourceSpans holds the spans for a single resource
+type OTLPResourceSpans struct {
+	Resource   OTLPResource     `json:"resource"`
+	ScopeSpans []OTLPScopeSpans `json:"scopeSpans"`
+}
+
+// OTLPResource describes the entity producing the spans
+type OTLPResource struct {
+	Attributes []OTLPKeyValue `json:"attributes"`
+}
+
+// OTLPScopeSpans holds the spans produced by a single instrumentation scope
+type OTLPScopeSpans struct {
+	Scope OTLPInstrumentationScope `json:"scope"`
+	Spans []OTLPSpan               `json:"spans"`
+}
+
+// OTLPInstrumentationScope identifies the library producing the spans
+type OTLPInstrumentationScope struct {
+	Name string `json:"name"`
+}
+
+// OTLPSpan is a single span; ids are hex encoded and times are nanoseconds since the unix epoch encoded as string
+type OTLPSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []OTLPKeyValue `json:"attributes,omitempty"`
+	Status            OTLPStatus     `json:"status"`
+}
+
+// OTLPKeyValue is a single attribute
+type OTLPKeyValue struct {
+	Key   string       `json:"key"`
+	Value OTLPAnyValue `json:"value"`
+}
+
+// OTLPAnyValue holds exactly one of its values; integers are encoded as string
+type OTLPAnyValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+// OTLPStatus is the status of a span; code 0 is unset, 1 is ok and 2 is error
+type OTLPStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// WriteOTLPJSON writes the log as OTLP json trace data
+func WriteOTLPJSON(w io.Writer, log JobLog, options TraceExportOptions) error {
+	return json.NewEncoder(w).Encode(GetTraceData(log, options))
+}
+
+// GetTraceData converts the log into a root span for the job with a child span for every stage, nested stage and
+// service and a span for pulling the image of a step; timing follows GetTimingReport, ids are derived from the job
+// so exporting the same log twice results in the same spans
+func GetTraceData(log JobLog, options TraceExportOptions) OTLPTraceData {
+
+	if options.ServiceName == "" {
+		options.ServiceName = DefaultTraceServiceName
+	}
+
+	report := GetTimingReport(log.GetSteps())
+
+	start := options.StartTime
+	if start.IsZero() {
+		start = log.GetInsertedAt().Add(-report.TotalDuration)
+	}
+
+	traceID := getTraceID(log)
+
+	rootAttributes := []OTLPKeyValue{
+		getOTLPStringAttribute("ziplinee.job.type", string(log.GetJobType())),
+		getOTLPStringAttribute("ziplinee.job.id", log.GetJobID()),
+		getOTLPStringAttribute("ziplinee.repo", log.GetFullRepoPath()),
+	}
+	if buildLog, ok := log.(*BuildLog); ok {
+		rootAttributes = append(rootAttributes,
+			getOTLPStringAttribute("ziplinee.repo.branch", buildLog.RepoBranch),
+			getOTLPStringAttribute("ziplinee.repo.revision", buildLog.RepoRevision),
+		)
+	}
+	aggregatedStatus := log.GetAggregatedStatus()
+	rootAttributes = append(rootAttributes, getOTLPStringAttribute("ziplinee.status", string(aggregatedStatus)))
+
+	rootSpan := OTLPSpan{
+		TraceID:           traceID,
+		SpanID:            getSpanID(traceID, "job"),
+		Name:              fmt.Sprintf("%v %v", log.GetJobType(), log.GetFullRepoPath()),
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: getOTLPTime(start),
+		EndTimeUnixNano:   getOTLPTime(start.Add(report.TotalDuration)),
+		Attributes:        rootAttributes,
+		Status:            getOTLPStatus(aggregatedStatus, ""),
+	}
+	spans := []OTLPSpan{rootSpan}
+
+	// the timing entries follow the same depth-first order as the walk, so the parent of a step is the last span one level up
+	parents := []string{}
+	i := 0
+	walkBuildLogSteps(log.GetSteps(), func(path []string, step *BuildLogStep, logType LogType) {
+		entry := report.Entries[i]
+		key := fmt.Sprintf("%v#%v#%v#%v", logType, entry.StepPath, step.RunIndex, i)
+		i++
+
+		parentSpanID := rootSpan.SpanID
+		if len(path) > 1 {
+			parentSpanID = parents[len(path)-2]
+		}
+
+		attributes := []OTLPKeyValue{
+			getOTLPStringAttribute("ziplinee.step.path", entry.StepPath),
+			getOTLPStringAttribute("ziplinee.step.type", string(logType)),
+			getOTLPIntAttribute("ziplinee.step.run_index", int64(step.RunIndex)),
+			getOTLPIntAttribute("ziplinee.step.exit_code", step.ExitCode),
+			getOTLPStringAttribute("ziplinee.step.status", string(step.Status)),
+		}
+		if step.AutoInjected {
+			attributes = append(attributes, getOTLPBoolAttribute("ziplinee.step.auto_injected", true))
+		}
+		if step.Image != nil {
+			attributes = append(attributes,
+				getOTLPStringAttribute("container.image.name", step.Image.Name),
+				getOTLPStringAttribute("container.image.tag", step.Image.Tag),
+			)
+		}
+
+		message := ""
+		if step.Status == LogStatusFailed {
+			message = fmt.Sprintf("%v failed with exit code %v", entry.StepPath, step.ExitCode)
+		}
+
+		span := OTLPSpan{
+			TraceID:           traceID,
+			SpanID:            getSpanID(traceID, key),
+			ParentSpanID:      parentSpanID,
+			Name:              entry.StepPath,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: getOTLPTime(start.Add(entry.Offset)),
+			EndTimeUnixNano:   getOTLPTime(start.Add(entry.End())),
+			Attributes:        attributes,
+			Status:            getOTLPStatus(step.Status, message),
+		}
+		spans = append(spans, span)
+
+		if step.Image != nil && (step.Image.PullDuration > 0 || step.Image.Error != "") {
+			pullAttributes := []OTLPKeyValue{
+				getOTLPStringAttribute("container.image.name", step.Image.Name),
+				getOTLPStringAttribute("container.image.tag", step.Image.Tag),
+				getOTLPBoolAttribute("ziplinee.image.pulled", step.Image.IsPulled),
+				getOTLPIntAttribute("ziplinee.image.size", step.Image.ImageSize),
+			}
+			pullStatus := OTLPStatus{Code: otlpStatusCodeOk}
+			if step.Image.Error != "" {
+				pullStatus = OTLPStatus{Code: otlpStatusCodeError, Message: step.Image.Error}
+			}
+
+			spans = append(spans, OTLPSpan{
+				TraceID:           traceID,
+				SpanID:            getSpanID(traceID, key+"#pull"),
+				ParentSpanID:      span.SpanID,
+				Name:              fmt.Sprintf("pull %v:%v", step.Image.Name, step.Image.Tag),
+				Kind:              otlpSpanKindInternal,
+				StartTimeUnixNano: getOTLPTime(start.Add(entry.Offset)),
+				EndTimeUnixNano:   getOTLPTime(start.Add(entry.Offset + entry.PullDuration)),
+				Attributes:        pullAttributes,
+				Status:            pullStatus,
+			})
+		}
+
+		parents = append(parents[:len(path)-1], span.SpanID)
+	})
+
+	return OTLPTraceData{
+		ResourceSpans: []OTLPResourceSpans{
+			{
+				Resource: OTLPResource{
+					Attributes: []OTLPKeyValue{
+						getOTLPStringAttribute("service.name", options.ServiceName),
+					},
+				},
+				ScopeSpans: []OTLPScopeSpans{
+					{
+						Scope: OTLPInstrumentationScope{Name: otlpScopeName},
+						Spans: spans,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getTraceID returns a 16 byte hex encoded trace id derived from the job type, repository and job id
+func getTraceID(log JobLog) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{string(log.GetJobType()), log.GetFullRepoPath(), log.GetJobID()}, "/")))
+	return hex.EncodeToString(hash[:16])
+}
+
+// getSpanID returns an 8 byte hex encoded span id derived from the trace id and a key unique within the trace
+func getSpanID(traceID, key string) string {
+	hash := sha256.Sum256([]byte(traceID + "/" + key))
+	return hex.EncodeToString(hash[:8])
+}
+
+func getOTLPTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func getOTLPStatus(status LogStatus, message string) OTLPStatus {
+	switch status {
+	case LogStatusSucceeded:
+		return OTLPStatus{Code: otlpStatusCodeOk}
+	case LogStatusFailed:
+		return OTLPStatus{Code: otlpStatusCodeError, Message: message}
+	}
+
+	return OTLPStatus{}
+}
+
+func getOTLPStringAttribute(key, value string) OTLPKeyValue {
+	return OTLPKeyValue{Key: key, Value: OTLPAnyValue{StringValue: &value}}
+}
+
+func getOTLPIntAttribute(key string, value int64) OTLPKeyValue {
+	intValue := strconv.FormatInt(value, 10)
+	return OTLPKeyValue{Key: key, Value: OTLPAnyValue{IntValue: &intValue}}
+}
+
+func getOTLPBoolAttribute(key string, value bool) OTLPKeyValue {
+	return OTLPKeyValue{Key: key, Value: OTLPAnyValue{BoolValue: &value}}
+}
@@ -0,0 +1,127 @@
+package contracts
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTraceData(t *testing.T) {
+	t.Run("ReturnsRootSpanWithChildSpanPerStepAndPullSpans", func(t *testing.T) {
+
+		buildLog := getTraceBuildLog()
+
+		// act
+		traceData := GetTraceData(&buildLog, TraceExportOptions{})
+
+		spans := traceData.ResourceSpans[0].ScopeSpans[0].Spans
+		if assert.Equal(t, 7, len(spans)) {
+			root := spans[0]
+			assert.Equal(t, "build github.com/ziplineeci/ziplinee-ci-api", root.Name)
+			assert.Equal(t, "", root.ParentSpanID)
+			assert.Equal(t, getOTLPTime(time.Date(2018, 4, 17, 8, 2, 33, 0, time.UTC)), root.StartTimeUnixNano)
+			assert.Equal(t, getOTLPTime(time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC)), root.EndTimeUnixNano)
+			assert.Equal(t, OTLPStatus{Code: 1}, root.Status)
+
+			assert.Equal(t, []string{"build", "pull golang:1.22", "build/lint", "build/postgres", "test", "test"},
+				[]string{spans[1].Name, spans[2].Name, spans[3].Name, spans[4].Name, spans[5].Name, spans[6].Name})
+
+			build := spans[1]
+			assert.Equal(t, root.SpanID, build.ParentSpanID)
+			assert.Equal(t, getOTLPTime(time.Date(2018, 4, 17, 8, 2, 45, 0, time.UTC)), build.EndTimeUnixNano)
+
+			pull := spans[2]
+			assert.Equal(t, build.SpanID, pull.ParentSpanID)
+			assert.Equal(t, build.StartTimeUnixNano, pull.StartTimeUnixNano)
+			assert.Equal(t, getOTLPTime(time.Date(2018, 4, 17, 8, 2, 35, 0, time.UTC)), pull.EndTimeUnixNano)
+
+			assert.Equal(t, build.SpanID, spans[3].ParentSpanID)
+			assert.Equal(t, build.SpanID, spans[4].ParentSpanID)
+			assert.Equal(t, getOTLPTime(time.Date(2018, 4, 17, 8, 2, 35, 0, time.UTC)), spans[4].StartTimeUnixNano)
+
+			failed := spans[5]
+			assert.Equal(t, root.SpanID, failed.ParentSpanID)
+			assert.Equal(t, OTLPStatus{Code: 2, Message: "test failed with exit code 1"}, failed.Status)
+			assert.NotEqual(t, failed.SpanID, spans[6].SpanID)
+		}
+	})
+
+	t.Run("SetsAttributesForRepoAndSteps", func(t *testing.T) {
+
+		buildLog := getTraceBuildLog()
+
+		// act
+		traceData := GetTraceData(&buildLog, TraceExportOptions{ServiceName: "ci"})
+
+		assert.Equal(t, "ci", *traceData.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+		spans := traceData.ResourceSpans[0].ScopeSpans[0].Spans
+		rootAttributes := getTraceAttributes(spans[0])
+		assert.Equal(t, "github.com/ziplineeci/ziplinee-ci-api", *rootAttributes["ziplinee.repo"].StringValue)
+		assert.Equal(t, "master", *rootAttributes["ziplinee.repo.branch"].StringValue)
+		assert.Equal(t, "as23456", *rootAttributes["ziplinee.repo.revision"].StringValue)
+		assert.Equal(t, "15", *rootAttributes["ziplinee.job.id"].StringValue)
+		failedAttributes := getTraceAttributes(spans[5])
+		assert.Equal(t, "1", *failedAttributes["ziplinee.step.exit_code"].IntValue)
+		assert.Equal(t, "FAILED", *failedAttributes["ziplinee.step.status"].StringValue)
+		buildAttributes := getTraceAttributes(spans[1])
+		assert.Equal(t, "golang", *buildAttributes["container.image.name"].StringValue)
+	})
+
+	t.Run("ReturnsSameIDsForSameJob", func(t *testing.T) {
+
+		buildLog := getTraceBuildLog()
+		releaseLog := &ReleaseLog{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", ReleaseID: "15", Steps: buildLog.Steps}
+
+		// act
+		first := GetTraceData(&buildLog, TraceExportOptions{})
+		second := GetTraceData(&buildLog, TraceExportOptions{})
+		release := GetTraceData(releaseLog, TraceExportOptions{})
+
+		assert.Equal(t, first, second)
+		assert.Equal(t, 32, len(first.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceID))
+		assert.Equal(t, 16, len(first.ResourceSpans[0].ScopeSpans[0].Spans[0].SpanID))
+		assert.NotEqual(t, first.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceID, release.ResourceSpans[0].ScopeSpans[0].Spans[0].TraceID)
+	})
+
+	t.Run("WritesOTLPJSON", func(t *testing.T) {
+
+		buildLog := getTraceBuildLog()
+		var buffer bytes.Buffer
+
+		// act
+		err := WriteOTLPJSON(&buffer, &buildLog, TraceExportOptions{StartTime: time.Unix(0, 0)})
+
+		assert.Nil(t, err)
+		var traceData map[string]interface{}
+		err = json.Unmarshal(buffer.Bytes(), &traceData)
+		assert.Nil(t, err)
+		assert.Contains(t, buffer.String(), `"startTimeUnixNano":"0"`)
+		assert.Contains(t, buffer.String(), `"intValue":"1"`)
+		assert.Contains(t, buffer.String(), `"scope":{"name":"github.com/ziplineeci/ziplinee-ci-contracts"}`)
+	})
+}
+
+func getTraceBuildLog() BuildLog {
+	buildLog := getExportBuildLog()
+	buildLog.Steps[0].Image = &BuildLogStepDockerImage{
+		Name:         "golang",
+		Tag:          "1.22",
+		IsPulled:     true,
+		ImageSize:    135000,
+		PullDuration: 2 * time.Second,
+	}
+
+	return buildLog
+}
+
+func getTraceAttributes(span OTLPSpan) map[string]OTLPAnyValue {
+	attributes := map[string]OTLPAnyValue{}
+	for _, a := range span.Attributes {
+		attributes[a.Key] = a.Value
+	}
+
+	return attributes
+}
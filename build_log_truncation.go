@@ -0,0 +1,290 @@
+package contracts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogTruncationPolicy limits the number of log lines and bytes kept per step and per log; a value of 0 means no limit
+type LogTruncationPolicy struct {
+	MaxLinesPerStep int `json:"maxLinesPerStep,omitempty"`
+	MaxBytesPerStep int `json:"maxBytesPerStep,omitempty"`
+	MaxLinesPerLog  int `json:"maxLinesPerLog,omitempty"`
+	MaxBytesPerLog  int `json:"maxBytesPerLog,omitempty"`
+	// TailLines is the number of lines at the end of a step that are kept when the step gets truncated
+	TailLines int `json:"tailLines,omitempty"`
+}
+
+// logTruncationMarkerBytes is the room reserved in the byte budgets for the line marking omitted lines, enough for a
+// marker of up to 999999999 lines and bytes
+const logTruncationMarkerBytes = 48
+
+// LogTruncationStats reports how many lines and bytes were retained and omitted; the retained lines and bytes include
+// the marker lines
+type LogTruncationStats struct {
+	TotalLines     int `json:"totalLines"`
+	TotalBytes     int `json:"totalBytes"`
+	RetainedLines  int `json:"retainedLines"`
+	RetainedBytes  int `json:"retainedBytes"`
+	OmittedLines   int `json:"omittedLines"`
+	OmittedBytes   int `json:"omittedBytes"`
+	TruncatedSteps int `json:"truncatedSteps"`
+}
+
+// IsTruncated returns true if any line was omitted
+func (stats LogTruncationStats) IsTruncated() bool {
+	return stats.OmittedLines > 0
+}
+
+// LogTruncator applies a LogTruncationPolicy to log lines while they're being streamed; lines at the start of a step
+// are passed through immediately, the lines at the end of a step are held back until the step finishes
+type LogTruncator struct {
+	policy    LogTruncationPolicy
+	steps     map[string]*stepTruncationState
+	stats     LogTruncationStats
+	usedLines int
+	usedBytes int
+}
+
+type stepTruncationState struct {
+	lineCount     int
+	headLines     int
+	headBytes     int
+	overflowed    bool
+	firstOverflow *BuildLogLine
+	tail          []BuildLogLine
+	tailBytes     int
+	omittedLines  int
+	omittedBytes  int
+}
+
+// NewLogTruncator returns a LogTruncator for the policy
+func NewLogTruncator(policy LogTruncationPolicy) *LogTruncator {
+	// one line of each step is reserved for the marker
+	if policy.MaxLinesPerStep > 0 && policy.TailLines > policy.MaxLinesPerStep-1 {
+		policy.TailLines = policy.MaxLinesPerStep - 1
+	}
+	if policy.TailLines < 0 {
+		policy.TailLines = 0
+	}
+
+	return &LogTruncator{
+		policy: policy,
+		steps:  map[string]*stepTruncationState{},
+	}
+}
+
+// AddLine registers a log line for the step identified by key (for example the step path and run index) and returns the lines that can be forwarded right away
+func (t *LogTruncator) AddLine(key string, line BuildLogLine) []BuildLogLine {
+
+	state, ok := t.steps[key]
+	if !ok {
+		state = &stepTruncationState{}
+		t.steps[key] = state
+	}
+
+	state.lineCount++
+	if line.LineNumber == 0 {
+		line.LineNumber = state.lineCount
+	}
+
+	size := len(line.Text)
+	t.stats.TotalLines++
+	t.stats.TotalBytes += size
+
+	if !state.overflowed && t.fitsInHead(state, size) {
+		state.headLines++
+		state.headBytes += size
+		t.retain(size)
+		return []BuildLogLine{line}
+	}
+
+	// from here on all lines of the step are candidates for the tail
+	if !state.overflowed {
+		state.overflowed = true
+		firstOverflow := line
+		state.firstOverflow = &firstOverflow
+	}
+
+	state.tail = append(state.tail, line)
+	state.tailBytes += size
+
+	for len(state.tail) > 0 && !t.fitsInTail(len(state.tail), state.tailBytes) {
+		state.omittedLines++
+		state.omittedBytes += len(state.tail[0].Text)
+		state.tailBytes -= len(state.tail[0].Text)
+		state.tail = state.tail[1:]
+	}
+
+	return []BuildLogLine{}
+}
+
+// FinishStep returns the remaining lines for the step identified by key, preceded by a marker line if any lines were
+// omitted; the marker counts towards the budgets, so older tail lines are dropped if needed to make room for it
+func (t *LogTruncator) FinishStep(key string) []BuildLogLine {
+
+	state, ok := t.steps[key]
+	if !ok {
+		return []BuildLogLine{}
+	}
+	delete(t.steps, key)
+
+	tail := state.tail
+	tailBytes := state.tailBytes
+	if state.omittedLines == 0 && t.fitsInStep(state, len(tail), tailBytes) {
+		return t.retainLines(tail)
+	}
+
+	// keep the newest tail lines that fit in the budgets together with the marker
+	marker, markerIsOmittedLine := getTruncationMarker(state)
+	for len(tail) > 0 && !t.fitsInStep(state, len(tail)+1, tailBytes+len(marker.Text)) {
+		state.omittedLines++
+		state.omittedBytes += len(tail[0].Text)
+		tailBytes -= len(tail[0].Text)
+		tail = tail[1:]
+		marker, markerIsOmittedLine = getTruncationMarker(state)
+	}
+
+	remaining := make([]BuildLogLine, 0, len(tail)+1)
+	if markerIsOmittedLine && t.fitsInStep(state, len(tail)+1, tailBytes+len(marker.Text)) {
+		// a single omitted line that isn't longer than its marker is kept instead
+		remaining = append(remaining, t.retainLines([]BuildLogLine{marker})...)
+		return append(remaining, t.retainLines(tail)...)
+	}
+
+	t.stats.OmittedLines += state.omittedLines
+	t.stats.OmittedBytes += state.omittedBytes
+	t.stats.TruncatedSteps++
+
+	// without any room left in the budgets the marker is left out as well
+	if t.fitsInStep(state, len(tail)+1, tailBytes+len(marker.Text)) {
+		remaining = append(remaining, t.retainLines([]BuildLogLine{marker})...)
+	}
+
+	return append(remaining, t.retainLines(tail)...)
+}
+
+// Stats returns the statistics for all steps finished so far
+func (t *LogTruncator) Stats() LogTruncationStats {
+	return t.stats
+}
+
+func (t *LogTruncator) retainLines(lines []BuildLogLine) []BuildLogLine {
+	for _, l := range lines {
+		t.retain(len(l.Text))
+	}
+
+	return lines
+}
+
+func (t *LogTruncator) retain(size int) {
+	t.usedLines++
+	t.usedBytes += size
+	t.stats.RetainedLines++
+	t.stats.RetainedBytes += size
+}
+
+func (t *LogTruncator) fitsInHead(state *stepTruncationState, size int) bool {
+	// leave room for the tail and the marker of this step
+	if t.policy.MaxLinesPerStep > 0 && state.headLines+1 > t.policy.MaxLinesPerStep-t.policy.TailLines-1 {
+		return false
+	}
+	if t.policy.MaxBytesPerStep > 0 && state.headBytes+size > t.policy.MaxBytesPerStep-t.tailBytesBudget()-logTruncationMarkerBytes {
+		return false
+	}
+
+	return t.fitsInLog(1+t.policy.TailLines+1, size+logTruncationMarkerBytes)
+}
+
+// fitsInStep returns true if the lines and bytes fit in the budgets for the step next to its head lines and in the
+// budget for the entire log
+func (t *LogTruncator) fitsInStep(state *stepTruncationState, lines, bytes int) bool {
+	if t.policy.MaxLinesPerStep > 0 && state.headLines+lines > t.policy.MaxLinesPerStep {
+		return false
+	}
+	if t.policy.MaxBytesPerStep > 0 && state.headBytes+bytes > t.policy.MaxBytesPerStep {
+		return false
+	}
+
+	return t.fitsInLog(lines, bytes)
+}
+
+func (t *LogTruncator) fitsInTail(lines, bytes int) bool {
+	if t.policy.TailLines <= 0 {
+		return false
+	}
+	if lines > t.policy.TailLines {
+		return false
+	}
+	if t.policy.MaxBytesPerStep > 0 && bytes > t.tailBytesBudget() {
+		return false
+	}
+
+	return true
+}
+
+func (t *LogTruncator) fitsInLog(lines, bytes int) bool {
+	if t.policy.MaxLinesPerLog > 0 && t.usedLines+lines > t.policy.MaxLinesPerLog {
+		return false
+	}
+	if t.policy.MaxBytesPerLog > 0 && t.usedBytes+bytes > t.policy.MaxBytesPerLog {
+		return false
+	}
+
+	return true
+}
+
+// getTruncationMarker returns the marker line for the omitted lines of the step, or the omitted line itself if only one
+// line was omitted and it isn't longer than the marker
+func getTruncationMarker(state *stepTruncationState) (marker BuildLogLine, isOmittedLine bool) {
+	marker = BuildLogLine{
+		LineNumber: state.firstOverflow.LineNumber,
+		Timestamp:  state.firstOverflow.Timestamp,
+		StreamType: state.firstOverflow.StreamType,
+		Text:       fmt.Sprintf("... %v lines (%v bytes) omitted ...", state.omittedLines, state.omittedBytes),
+	}
+	if state.omittedLines == 1 && len(state.firstOverflow.Text) <= len(marker.Text) {
+		return *state.firstOverflow, true
+	}
+
+	return marker, false
+}
+
+// tailBytesBudget splits the bytes per step evenly between head and tail if any tail lines are kept
+func (t *LogTruncator) tailBytesBudget() int {
+	if t.policy.TailLines <= 0 || t.policy.MaxBytesPerStep <= 0 {
+		return 0
+	}
+
+	return t.policy.MaxBytesPerStep / 2
+}
+
+// TruncateJobLog applies the truncation policy to all steps of the build, release or bot log
+func TruncateJobLog(log JobLog, policy LogTruncationPolicy) LogTruncationStats {
+	return TruncateSteps(log.GetSteps(), policy)
+}
+
+// TruncateSteps applies the truncation policy to the log lines of all steps, nested steps and services in place;
+// the budget for the entire log is handed out to steps in the order they appear
+func TruncateSteps(steps []*BuildLogStep, policy LogTruncationPolicy) LogTruncationStats {
+
+	truncator := NewLogTruncator(policy)
+
+	walkBuildLogSteps(steps, func(path []string, step *BuildLogStep, logType LogType) {
+		if len(step.LogLines) == 0 {
+			return
+		}
+
+		key := fmt.Sprintf("%v#%v", strings.Join(path, "/"), step.RunIndex)
+
+		lines := make([]BuildLogLine, 0, len(step.LogLines))
+		for _, l := range step.LogLines {
+			lines = append(lines, truncator.AddLine(key, l)...)
+		}
+		lines = append(lines, truncator.FinishStep(key)...)
+
+		step.LogLines = lines
+	})
+
+	return truncator.Stats()
+}
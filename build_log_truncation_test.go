@@ -0,0 +1,234 @@
+package contracts
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateSteps(t *testing.T) {
+	t.Run("KeepsAllLinesIfWithinLimits", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 5),
+		}
+
+		// act
+		stats := TruncateSteps(steps, LogTruncationPolicy{MaxLinesPerStep: 10, TailLines: 3})
+
+		assert.False(t, stats.IsTruncated())
+		assert.Equal(t, 5, len(steps[0].LogLines))
+		assert.Equal(t, 5, stats.TotalLines)
+		assert.Equal(t, 5, stats.RetainedLines)
+		assert.Equal(t, 0, stats.TruncatedSteps)
+	})
+
+	t.Run("KeepsHeadAndTailAndInsertsMarkerLine", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 20),
+		}
+
+		// act
+		stats := TruncateSteps(steps, LogTruncationPolicy{MaxLinesPerStep: 6, TailLines: 2})
+
+		lines := steps[0].LogLines
+		if assert.Equal(t, 6, len(lines)) {
+			assert.Equal(t, "line 1", lines[0].Text)
+			assert.Equal(t, "line 3", lines[2].Text)
+			assert.Equal(t, "... 15 lines (99 bytes) omitted ...", lines[3].Text)
+			assert.Equal(t, 4, lines[3].LineNumber)
+			assert.Equal(t, "line 19", lines[4].Text)
+			assert.Equal(t, 19, lines[4].LineNumber)
+			assert.Equal(t, "line 20", lines[5].Text)
+		}
+		assert.True(t, stats.IsTruncated())
+		assert.Equal(t, 20, stats.TotalLines)
+		assert.Equal(t, 6, stats.RetainedLines)
+		assert.Equal(t, 15, stats.OmittedLines)
+		assert.Equal(t, 99, stats.OmittedBytes)
+		assert.Equal(t, 1, stats.TruncatedSteps)
+	})
+
+	t.Run("CountsMarkerLineInBudgets", func(t *testing.T) {
+
+		perStepSteps := []*BuildLogStep{
+			getTruncationStep("build", 20),
+		}
+		perLogSteps := []*BuildLogStep{
+			getTruncationStep("build", 20),
+			getTruncationStep("test", 20),
+		}
+
+		// act
+		perStepStats := TruncateSteps(perStepSteps, LogTruncationPolicy{MaxLinesPerStep: 10, TailLines: 3})
+		perLogStats := TruncateSteps(perLogSteps, LogTruncationPolicy{MaxLinesPerLog: 15, TailLines: 3})
+
+		assert.Equal(t, 10, len(perStepSteps[0].LogLines))
+		assert.Equal(t, 10, perStepStats.RetainedLines)
+		assert.Equal(t, 15, len(perLogSteps[0].LogLines)+len(perLogSteps[1].LogLines))
+		assert.Equal(t, 15, perLogStats.RetainedLines)
+	})
+
+	t.Run("KeepsSingleOmittedLineInsteadOfMarker", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 10),
+		}
+
+		// act
+		stats := TruncateSteps(steps, LogTruncationPolicy{MaxLinesPerStep: 10, TailLines: 3})
+
+		lines := steps[0].LogLines
+		if assert.Equal(t, 10, len(lines)) {
+			assert.Equal(t, "line 7", lines[6].Text)
+		}
+		assert.False(t, stats.IsTruncated())
+		assert.Equal(t, 10, stats.RetainedLines)
+	})
+
+	t.Run("AssignsLineNumbersIfMissing", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 10),
+		}
+		for i := range steps[0].LogLines {
+			steps[0].LogLines[i].LineNumber = 0
+		}
+
+		// act
+		TruncateSteps(steps, LogTruncationPolicy{MaxLinesPerStep: 4, TailLines: 2})
+
+		lines := steps[0].LogLines
+		if assert.Equal(t, 4, len(lines)) {
+			assert.Equal(t, 1, lines[0].LineNumber)
+			assert.Equal(t, 2, lines[1].LineNumber)
+			assert.Equal(t, 9, lines[2].LineNumber)
+			assert.Equal(t, 10, lines[3].LineNumber)
+		}
+	})
+
+	t.Run("LimitsBytesPerStep", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 20),
+		}
+
+		// act
+		stats := TruncateSteps(steps, LogTruncationPolicy{MaxBytesPerStep: 110, TailLines: 9})
+
+		lines := steps[0].LogLines
+		if assert.Equal(t, 9, len(lines)) {
+			assert.Equal(t, "line 1", lines[0].Text)
+			assert.Equal(t, "... 12 lines (76 bytes) omitted ...", lines[1].Text)
+			assert.Equal(t, "line 14", lines[2].Text)
+			assert.Equal(t, "line 20", lines[8].Text)
+		}
+		assert.Equal(t, 90, stats.RetainedBytes)
+	})
+
+	t.Run("HandsOutLogBudgetInStepOrder", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			getTruncationStep("build", 4),
+			getTruncationStep("test", 4),
+		}
+		steps[0].NestedSteps = []*BuildLogStep{
+			getTruncationStep("lint", 4),
+		}
+
+		// act
+		stats := TruncateSteps(steps, LogTruncationPolicy{MaxLinesPerLog: 7, TailLines: 1})
+
+		assert.Equal(t, 4, len(steps[0].LogLines))
+		if assert.Equal(t, 3, len(steps[0].NestedSteps[0].LogLines)) {
+			assert.Equal(t, "line 1", steps[0].NestedSteps[0].LogLines[0].Text)
+			assert.Equal(t, "... 2 lines (12 bytes) omitted ...", steps[0].NestedSteps[0].LogLines[1].Text)
+			assert.Equal(t, "line 4", steps[0].NestedSteps[0].LogLines[2].Text)
+		}
+		// without room left for a marker the step has no lines at all
+		assert.Equal(t, 0, len(steps[1].LogLines))
+		assert.Equal(t, 7, stats.RetainedLines)
+		assert.Equal(t, 6, stats.OmittedLines)
+		assert.Equal(t, 2, stats.TruncatedSteps)
+	})
+
+	t.Run("LeavesStepsWithoutLogLinesUntouched", func(t *testing.T) {
+
+		steps := []*BuildLogStep{
+			&BuildLogStep{
+				Step: "build",
+			},
+		}
+
+		// act
+		TruncateSteps(steps, LogTruncationPolicy{MaxLinesPerStep: 4, TailLines: 2})
+
+		assert.Nil(t, steps[0].LogLines)
+	})
+}
+
+func TestLogTruncator(t *testing.T) {
+	t.Run("ForwardsHeadLinesImmediatelyAndTailLinesOnFinish", func(t *testing.T) {
+
+		truncator := NewLogTruncator(LogTruncationPolicy{MaxLinesPerStep: 3, TailLines: 1})
+		step := getTruncationStep("build", 5)
+
+		forwarded := []BuildLogLine{}
+		for _, l := range step.LogLines {
+			// act
+			forwarded = append(forwarded, truncator.AddLine("build", l)...)
+		}
+
+		if assert.Equal(t, 1, len(forwarded)) {
+			assert.Equal(t, "line 1", forwarded[0].Text)
+		}
+		assert.Equal(t, 0, truncator.Stats().OmittedLines)
+
+		// act
+		remaining := truncator.FinishStep("build")
+
+		if assert.Equal(t, 2, len(remaining)) {
+			assert.Equal(t, "... 3 lines (18 bytes) omitted ...", remaining[0].Text)
+			assert.Equal(t, "line 5", remaining[1].Text)
+		}
+		assert.Equal(t, 3, truncator.Stats().OmittedLines)
+	})
+
+	t.Run("TracksInterleavedStepsSeparately", func(t *testing.T) {
+
+		truncator := NewLogTruncator(LogTruncationPolicy{MaxLinesPerStep: 2})
+
+		// act
+		a1 := truncator.AddLine("stage-a#0", BuildLogLine{Text: "a1"})
+		b1 := truncator.AddLine("stage-b#0", BuildLogLine{Text: "b1"})
+		a2 := truncator.AddLine("stage-a#0", BuildLogLine{Text: "a2"})
+
+		assert.Equal(t, 1, len(a1))
+		assert.Equal(t, 1, len(b1))
+		assert.Equal(t, 0, len(a2))
+		assert.Equal(t, 0, len(truncator.FinishStep("stage-b#0")))
+		assert.Equal(t, 1, len(truncator.FinishStep("stage-a#0")))
+		assert.Equal(t, 0, len(truncator.FinishStep("stage-c#0")))
+	})
+}
+
+func getTruncationStep(name string, lines int) *BuildLogStep {
+	step := &BuildLogStep{
+		Step:     name,
+		Status:   LogStatusSucceeded,
+		LogLines: []BuildLogLine{},
+	}
+	for i := 1; i <= lines; i++ {
+		step.LogLines = append(step.LogLines, BuildLogLine{
+			LineNumber: i,
+			Timestamp:  time.Date(2018, 4, 17, 8, 3, i, 0, time.UTC),
+			StreamType: "stdout",
+			Text:       fmt.Sprintf("line %v", i),
+		})
+	}
+
+	return step
+}
@@ -0,0 +1,185 @@
+package contracts
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	manifest "github.com/ziplineeci/ziplinee-ci-manifest"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ManifestChange indicates how a value differs between the raw manifest and the manifest with defaults
+type ManifestChange string
+
+const (
+	// ManifestChangeAdded indicates the value is only set in the manifest with defaults
+	ManifestChangeAdded ManifestChange = "added"
+	// ManifestChangeRemoved indicates the value is only set in the raw manifest
+	ManifestChangeRemoved ManifestChange = "removed"
+	// ManifestChangeChanged indicates the value differs between both manifests
+	ManifestChangeChanged ManifestChange = "changed"
+)
+
+// ManifestDiffEntry is a single value that differs between the raw manifest and the manifest with defaults
+type ManifestDiffEntry struct {
+	// Path to the value, like stages.build.shell or triggers[0].pipeline.name
+	Path         string         `json:"path"`
+	Change       ManifestChange `json:"change"`
+	Raw          interface{}    `json:"raw,omitempty"`
+	WithDefaults interface{}    `json:"withDefaults,omitempty"`
+}
+
+// GetManifestObject returns ManifestObject, parsing Manifest with defaults from the preferences the first time it's
+// called; parse and validation errors are returned as warnings with status error and warning respectively. The result
+// of parsing, including a failed parse, is kept in ManifestObject and ManifestWarnings, so later calls return the same
+func (build *Build) GetManifestObject(preferences *manifest.ZiplineeManifestPreferences) (*manifest.ZiplineeManifest, []Warning) {
+	if build.ManifestObject != nil || len(build.ManifestWarnings) > 0 {
+		return build.ManifestObject, build.ManifestWarnings
+	}
+
+	build.ManifestObject, build.ManifestWarnings = parseManifestObject(build.Manifest, build.ManifestWithDefaults, preferences)
+
+	return build.ManifestObject, build.ManifestWarnings
+}
+
+// GetManifestObject returns ManifestObject, parsing Manifest with defaults from the preferences the first time it's
+// called; parse and validation errors are returned as warnings with status error and warning respectively. The result
+// of parsing, including a failed parse, is kept in ManifestObject and ManifestWarnings, so later calls return the same
+func (pipeline *Pipeline) GetManifestObject(preferences *manifest.ZiplineeManifestPreferences) (*manifest.ZiplineeManifest, []Warning) {
+	if pipeline.ManifestObject != nil || len(pipeline.ManifestWarnings) > 0 {
+		return pipeline.ManifestObject, pipeline.ManifestWarnings
+	}
+
+	pipeline.ManifestObject, pipeline.ManifestWarnings = parseManifestObject(pipeline.Manifest, pipeline.ManifestWithDefaults, preferences)
+
+	return pipeline.ManifestObject, pipeline.ManifestWarnings
+}
+
+// GetManifestDefaultsDiff returns the values set by defaults in ManifestWithDefaults compared to Manifest
+func (build *Build) GetManifestDefaultsDiff(preferences *manifest.ZiplineeManifestPreferences) ([]ManifestDiffEntry, error) {
+	return GetManifestDefaultsDiff(build.Manifest, build.ManifestWithDefaults, preferences)
+}
+
+// GetManifestDefaultsDiff returns the values set by defaults in ManifestWithDefaults compared to Manifest
+func (pipeline *Pipeline) GetManifestDefaultsDiff(preferences *manifest.ZiplineeManifestPreferences) ([]ManifestDiffEntry, error) {
+	return GetManifestDefaultsDiff(pipeline.Manifest, pipeline.ManifestWithDefaults, preferences)
+}
+
+// GetManifestDefaultsDiff compares the structure of the raw manifest with the manifest with defaults; if the latter
+// is empty it's generated from the raw manifest and the preferences
+func GetManifestDefaultsDiff(rawManifest, manifestWithDefaults string, preferences *manifest.ZiplineeManifestPreferences) ([]ManifestDiffEntry, error) {
+
+	if manifestWithDefaults == "" {
+		mft, err := manifest.ReadManifest(preferences, rawManifest, false)
+		if err != nil {
+			return nil, err
+		}
+		bytes, err := yaml.Marshal(mft)
+		if err != nil {
+			return nil, err
+		}
+		manifestWithDefaults = string(bytes)
+	}
+
+	var raw, withDefaults interface{}
+	if err := yaml.Unmarshal([]byte(rawManifest), &raw); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal([]byte(manifestWithDefaults), &withDefaults); err != nil {
+		return nil, err
+	}
+
+	entries := []ManifestDiffEntry{}
+	diffManifestValues("", cleanUpMapValue(raw), cleanUpMapValue(withDefaults), &entries)
+
+	return entries, nil
+}
+
+func parseManifestObject(rawManifest, manifestWithDefaults string, preferences *manifest.ZiplineeManifestPreferences) (*manifest.ZiplineeManifest, []Warning) {
+
+	manifestString := rawManifest
+	if manifestString == "" {
+		manifestString = manifestWithDefaults
+	}
+	if manifestString == "" {
+		return nil, nil
+	}
+
+	if preferences == nil {
+		preferences = manifest.GetDefaultManifestPreferences()
+	}
+
+	mft, err := manifest.ReadManifest(preferences, manifestString, false)
+	if err != nil {
+		return nil, []Warning{{Status: "error", Message: fmt.Sprintf("Manifest can't be parsed: %v", err)}}
+	}
+
+	var warnings []Warning
+	if err := mft.Validate(*preferences); err != nil {
+		warnings = append(warnings, Warning{Status: "warning", Message: fmt.Sprintf("Manifest is invalid: %v", err)})
+	}
+
+	return &mft, warnings
+}
+
+func diffManifestValues(path string, raw, withDefaults interface{}, entries *[]ManifestDiffEntry) {
+
+	if raw == nil && withDefaults == nil {
+		return
+	}
+
+	// maps missing on one side are compared with an empty map to report every value in them separately
+	rawMap, rawIsMap := raw.(map[string]interface{})
+	withDefaultsMap, withDefaultsIsMap := withDefaults.(map[string]interface{})
+	if rawIsMap && withDefaults == nil || withDefaultsIsMap && raw == nil || rawIsMap && withDefaultsIsMap {
+		keys := []string{}
+		for k := range rawMap {
+			keys = append(keys, k)
+		}
+		for k := range withDefaultsMap {
+			if _, ok := rawMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			diffManifestValues(childPath, rawMap[k], withDefaultsMap[k], entries)
+		}
+		return
+	}
+
+	rawArray, rawIsArray := raw.([]interface{})
+	withDefaultsArray, withDefaultsIsArray := withDefaults.([]interface{})
+	if rawIsArray && withDefaultsIsArray {
+		for i := 0; i < len(rawArray) || i < len(withDefaultsArray); i++ {
+			var rawItem, withDefaultsItem interface{}
+			if i < len(rawArray) {
+				rawItem = rawArray[i]
+			}
+			if i < len(withDefaultsArray) {
+				withDefaultsItem = withDefaultsArray[i]
+			}
+			diffManifestValues(fmt.Sprintf("%v[%v]", path, i), rawItem, withDefaultsItem, entries)
+		}
+		return
+	}
+
+	switch {
+	case raw == nil:
+		*entries = append(*entries, ManifestDiffEntry{Path: path, Change: ManifestChangeAdded, WithDefaults: withDefaults})
+		return
+	case withDefaults == nil:
+		*entries = append(*entries, ManifestDiffEntry{Path: path, Change: ManifestChangeRemoved, Raw: raw})
+		return
+	}
+
+	if !reflect.DeepEqual(raw, withDefaults) {
+		*entries = append(*entries, ManifestDiffEntry{Path: path, Change: ManifestChangeChanged, Raw: raw, WithDefaults: withDefaults})
+	}
+}
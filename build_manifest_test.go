@@ -0,0 +1,142 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	manifest "github.com/ziplineeci/ziplinee-ci-manifest"
+)
+
+func TestBuildGetManifestObject(t *testing.T) {
+	t.Run("ParsesManifestWithDefaults", func(t *testing.T) {
+
+		build := &Build{Manifest: getTestManifest()}
+
+		// act
+		mft, warnings := build.GetManifestObject(nil)
+
+		assert.Nil(t, warnings)
+		if assert.NotNil(t, mft) && assert.Equal(t, 1, len(mft.Stages)) {
+			assert.Equal(t, "build", mft.Stages[0].Name)
+			assert.Equal(t, "/bin/sh", mft.Stages[0].Shell)
+			assert.Equal(t, "stable", mft.Builder.Track)
+		}
+		assert.Same(t, mft, build.ManifestObject)
+	})
+
+	t.Run("ReturnsExistingManifestObject", func(t *testing.T) {
+
+		existing := &manifest.ZiplineeManifest{Archived: true}
+		build := &Build{Manifest: "invalid: [", ManifestObject: existing}
+
+		// act
+		mft, warnings := build.GetManifestObject(nil)
+
+		assert.Nil(t, warnings)
+		assert.Same(t, existing, mft)
+	})
+
+	t.Run("ReturnsParseErrorAsWarning", func(t *testing.T) {
+
+		build := &Build{Manifest: "stages: ["}
+
+		// act
+		mft, warnings := build.GetManifestObject(nil)
+
+		assert.Nil(t, mft)
+		if assert.Equal(t, 1, len(warnings)) {
+			assert.Equal(t, "error", warnings[0].Status)
+		}
+	})
+
+	t.Run("ReturnsValidationErrorAsWarning", func(t *testing.T) {
+
+		pipeline := &Pipeline{Manifest: "builder:\n  track: nightly\nstages:\n  build:\n    image: golang:1.22\n"}
+
+		// act
+		mft, warnings := pipeline.GetManifestObject(nil)
+
+		assert.NotNil(t, mft)
+		if assert.Equal(t, 1, len(warnings)) {
+			assert.Equal(t, "warning", warnings[0].Status)
+		}
+	})
+
+	t.Run("ReturnsSameWarningsOnLaterCalls", func(t *testing.T) {
+
+		pipeline := &Pipeline{Manifest: "builder:\n  track: nightly\nstages:\n  build:\n    image: golang:1.22\n"}
+		first, firstWarnings := pipeline.GetManifestObject(nil)
+
+		// act
+		mft, warnings := pipeline.GetManifestObject(nil)
+
+		assert.Equal(t, 1, len(firstWarnings))
+		assert.Equal(t, firstWarnings, warnings)
+		assert.Same(t, first, mft)
+	})
+
+	t.Run("CachesFailedParse", func(t *testing.T) {
+
+		build := &Build{Manifest: "stages: ["}
+		_, firstWarnings := build.GetManifestObject(nil)
+		build.Manifest = getTestManifest()
+
+		// act
+		mft, warnings := build.GetManifestObject(nil)
+
+		assert.Nil(t, mft)
+		assert.Equal(t, firstWarnings, warnings)
+		if assert.Equal(t, 1, len(warnings)) {
+			assert.Equal(t, "error", warnings[0].Status)
+		}
+	})
+}
+
+func TestGetManifestDefaultsDiff(t *testing.T) {
+	t.Run("ReturnsValuesAddedByDefaults", func(t *testing.T) {
+
+		build := &Build{Manifest: getTestManifest()}
+
+		// act
+		entries, err := build.GetManifestDefaultsDiff(nil)
+
+		assert.Nil(t, err)
+		changes := map[string]ManifestDiffEntry{}
+		for _, e := range entries {
+			changes[e.Path] = e
+		}
+		assert.Equal(t, ManifestDiffEntry{Path: "builder.track", Change: ManifestChangeAdded, WithDefaults: "stable"}, changes["builder.track"])
+		assert.Equal(t, ManifestDiffEntry{Path: "stages.build.shell", Change: ManifestChangeAdded, WithDefaults: "/bin/sh"}, changes["stages.build.shell"])
+		_, imageChanged := changes["stages.build.image"]
+		assert.False(t, imageChanged)
+	})
+
+	t.Run("ComparesWithStoredManifestWithDefaults", func(t *testing.T) {
+
+		pipeline := &Pipeline{
+			Manifest:             "labels:\n  app: api\nstages:\n  build:\n    image: golang:1.22\n",
+			ManifestWithDefaults: "labels:\n  app: web\nstages:\n  build:\n    image: golang:1.22\n    commands:\n    - go build\n",
+		}
+
+		// act
+		entries, err := pipeline.GetManifestDefaultsDiff(nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []ManifestDiffEntry{
+			ManifestDiffEntry{Path: "labels.app", Change: ManifestChangeChanged, Raw: "api", WithDefaults: "web"},
+			ManifestDiffEntry{Path: "stages.build.commands", Change: ManifestChangeAdded, WithDefaults: []interface{}{"go build"}},
+		}, entries)
+	})
+
+	t.Run("ReturnsErrorForInvalidManifest", func(t *testing.T) {
+
+		// act
+		_, err := GetManifestDefaultsDiff("stages: [", "", nil)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func getTestManifest() string {
+	return "labels:\n  app: ziplinee-ci-api\nstages:\n  build:\n    image: golang:1.22\n    commands:\n    - go build ./...\n"
+}
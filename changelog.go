@@ -0,0 +1,239 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// changelogSectionTitles holds the title of the changelog section per conventional commit type, in the order the sections are listed
+var changelogSectionTitles = []struct {
+	Type  string
+	Title string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"revert", "Reverts"},
+	{"refactor", "Code Refactoring"},
+	{"docs", "Documentation"},
+	{"test", "Tests"},
+	{"build", "Build System"},
+	{"ci", "Continuous Integration"},
+	{"style", "Styles"},
+	{"chore", "Chores"},
+}
+
+const (
+	// ChangelogSectionOther groups commits with an unknown type or messages that aren't conventional commits
+	ChangelogSectionOther = "other"
+
+	changelogSectionOtherTitle = "Other Changes"
+)
+
+// Changelog lists the changes in the builds between two releases of a pipeline, grouped by conventional commit type
+type Changelog struct {
+	FromVersion     string             `json:"fromVersion,omitempty"`
+	ToVersion       string             `json:"toVersion"`
+	BreakingChanges []ChangelogEntry   `json:"breakingChanges,omitempty"`
+	Sections        []ChangelogSection `json:"sections"`
+}
+
+// ChangelogSection holds the entries for a single conventional commit type
+type ChangelogSection struct {
+	Type    string           `json:"type"`
+	Title   string           `json:"title"`
+	Entries []ChangelogEntry `json:"entries"`
+}
+
+// ChangelogEntry is a single commit in a changelog
+type ChangelogEntry struct {
+	Scope           string    `json:"scope,omitempty"`
+	Description     string    `json:"description"`
+	Breaking        bool      `json:"breaking,omitempty"`
+	BreakingChange  string    `json:"breakingChange,omitempty"`
+	IssueReferences []string  `json:"issueReferences,omitempty"`
+	Author          GitAuthor `json:"author"`
+	BuildVersion    string    `json:"buildVersion,omitempty"`
+}
+
+// GetChangelog returns the changelog for the commits of the builds on the branch of the build for the to release,
+// inserted after the build for the from release up to and including the build for the to release; from can be nil to
+// include all builds up to the to release
+func GetChangelog(builds []*Build, from, to *Release) (Changelog, error) {
+
+	if to == nil {
+		return Changelog{}, errors.New("changelog needs a release to end at")
+	}
+
+	changelog := Changelog{
+		ToVersion: to.ReleaseVersion,
+		Sections:  []ChangelogSection{},
+	}
+
+	// oldest first, so commits are listed in the order they were built
+	sortedBuilds := make([]*Build, 0, len(builds))
+	for _, b := range builds {
+		if b != nil {
+			sortedBuilds = append(sortedBuilds, b)
+		}
+	}
+	sort.SliceStable(sortedBuilds, func(i, j int) bool {
+		return sortedBuilds[i].InsertedAt.Before(sortedBuilds[j].InsertedAt)
+	})
+
+	toBuild := getBuildForVersion(sortedBuilds, to.ReleaseVersion)
+	if toBuild == nil {
+		return changelog, fmt.Errorf("no build found for release version %v", to.ReleaseVersion)
+	}
+
+	var fromBuild *Build
+	if from != nil {
+		changelog.FromVersion = from.ReleaseVersion
+		fromBuild = getBuildForVersion(sortedBuilds, from.ReleaseVersion)
+		if fromBuild == nil {
+			return changelog, fmt.Errorf("no build found for release version %v", from.ReleaseVersion)
+		}
+		if fromBuild.InsertedAt.After(toBuild.InsertedAt) {
+			return changelog, fmt.Errorf("release version %v was built after release version %v", from.ReleaseVersion, to.ReleaseVersion)
+		}
+	}
+
+	entries := map[string][]ChangelogEntry{}
+	seen := map[string]bool{}
+	for _, b := range sortedBuilds {
+		if fromBuild != nil && !b.InsertedAt.After(fromBuild.InsertedAt) {
+			continue
+		}
+		if b.InsertedAt.After(toBuild.InsertedAt) {
+			break
+		}
+		// builds of other branches in the same time window didn't end up in the released version
+		if b.RepoBranch != toBuild.RepoBranch {
+			continue
+		}
+
+		// builds of the same push and rebuilds repeat commits
+		for _, c := range b.Commits {
+			key := getCommitKey(c)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			sectionType, entry := getChangelogEntry(c, b.BuildVersion)
+			entries[sectionType] = append(entries[sectionType], entry)
+			if entry.Breaking {
+				changelog.BreakingChanges = append(changelog.BreakingChanges, entry)
+			}
+		}
+	}
+
+	for _, s := range changelogSectionTitles {
+		if len(entries[s.Type]) > 0 {
+			changelog.Sections = append(changelog.Sections, ChangelogSection{Type: s.Type, Title: s.Title, Entries: entries[s.Type]})
+		}
+	}
+	if len(entries[ChangelogSectionOther]) > 0 {
+		changelog.Sections = append(changelog.Sections, ChangelogSection{Type: ChangelogSectionOther, Title: changelogSectionOtherTitle, Entries: entries[ChangelogSectionOther]})
+	}
+
+	return changelog, nil
+}
+
+// Markdown returns the changelog as markdown, with a heading per section and breaking changes listed first
+func (changelog *Changelog) Markdown() string {
+
+	var sb strings.Builder
+
+	title := changelog.ToVersion
+	if changelog.FromVersion != "" {
+		title = fmt.Sprintf("%v...%v", changelog.FromVersion, changelog.ToVersion)
+	}
+	fmt.Fprintf(&sb, "## %v\n", title)
+
+	if len(changelog.BreakingChanges) > 0 {
+		sb.WriteString("\n### ⚠ Breaking Changes\n\n")
+		for _, e := range changelog.BreakingChanges {
+			description := e.Description
+			if e.BreakingChange != "" {
+				description = e.BreakingChange
+			}
+			sb.WriteString(getChangelogMarkdownLine(e, description))
+		}
+	}
+
+	for _, s := range changelog.Sections {
+		fmt.Fprintf(&sb, "\n### %v\n\n", s.Title)
+		for _, e := range s.Entries {
+			sb.WriteString(getChangelogMarkdownLine(e, e.Description))
+		}
+	}
+
+	return sb.String()
+}
+
+func getChangelogEntry(commit GitCommit, buildVersion string) (string, ChangelogEntry) {
+
+	conventionalCommit, ok := commit.ParseConventionalCommit()
+	if !ok {
+		description, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		return ChangelogSectionOther, ChangelogEntry{
+			Description:  strings.TrimSpace(description),
+			Author:       commit.Author,
+			BuildVersion: buildVersion,
+		}
+	}
+
+	sectionType := ChangelogSectionOther
+	for _, s := range changelogSectionTitles {
+		if s.Type == conventionalCommit.Type {
+			sectionType = s.Type
+		}
+	}
+
+	return sectionType, ChangelogEntry{
+		Scope:           conventionalCommit.Scope,
+		Description:     conventionalCommit.Description,
+		Breaking:        conventionalCommit.Breaking,
+		BreakingChange:  conventionalCommit.BreakingChange,
+		IssueReferences: conventionalCommit.IssueReferences,
+		Author:          commit.Author,
+		BuildVersion:    buildVersion,
+	}
+}
+
+func getChangelogMarkdownLine(entry ChangelogEntry, description string) string {
+	line := "* "
+	if entry.Scope != "" {
+		line += fmt.Sprintf("**%v:** ", entry.Scope)
+	}
+	line += description
+	if len(entry.IssueReferences) > 0 {
+		line += fmt.Sprintf(" (%v)", strings.Join(entry.IssueReferences, ", "))
+	}
+
+	return line + "\n"
+}
+
+// getBuildForVersion returns the last build with the version
+func getBuildForVersion(builds []*Build, version string) *Build {
+	var build *Build
+	for _, b := range builds {
+		if b.BuildVersion == version {
+			build = b
+		}
+	}
+
+	return build
+}
+
+// getCommitKey returns a key identifying a commit, to deduplicate commits repeated in builds of the same push and rebuilds;
+// without a revision commits of the same author with the same message, like reverts, can't be told apart
+func getCommitKey(commit GitCommit) string {
+	if commit.Revision != "" {
+		return "revision:" + commit.Revision
+	}
+	return "message:" + commit.Author.Email + "\n" + commit.Message
+}
@@ -0,0 +1,140 @@
+package contracts
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetChangelog(t *testing.T) {
+	t.Run("GroupsCommitsOfBuildsBetweenReleases", func(t *testing.T) {
+
+		builds := getChangelogBuilds()
+
+		// act
+		changelog, err := GetChangelog(builds, getReleaseTargetsRelease("production", "", "1.0.1", StatusSucceeded, 10), getReleaseTargetsRelease("production", "", "1.0.4", StatusSucceeded, 20))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "1.0.1", changelog.FromVersion)
+		assert.Equal(t, "1.0.4", changelog.ToVersion)
+		if assert.Equal(t, 3, len(changelog.Sections)) {
+			assert.Equal(t, "feat", changelog.Sections[0].Type)
+			assert.Equal(t, 2, len(changelog.Sections[0].Entries))
+			assert.Equal(t, "add release targets", changelog.Sections[0].Entries[0].Description)
+			assert.Equal(t, "1.0.2", changelog.Sections[0].Entries[0].BuildVersion)
+			assert.Equal(t, "fix", changelog.Sections[1].Type)
+			assert.Equal(t, []string{"#7"}, changelog.Sections[1].Entries[0].IssueReferences)
+			assert.Equal(t, ChangelogSectionOther, changelog.Sections[2].Type)
+			assert.Equal(t, "Update readme", changelog.Sections[2].Entries[0].Description)
+		}
+		if assert.Equal(t, 1, len(changelog.BreakingChanges)) {
+			assert.Equal(t, "api", changelog.BreakingChanges[0].Scope)
+		}
+	})
+
+	t.Run("IncludesAllBuildsUpToReleaseWithoutFromRelease", func(t *testing.T) {
+
+		builds := getChangelogBuilds()
+
+		// act
+		changelog, err := GetChangelog(builds, nil, getReleaseTargetsRelease("production", "", "1.0.1", StatusSucceeded, 10))
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(changelog.Sections)) {
+			assert.Equal(t, "chore", changelog.Sections[0].Type)
+		}
+	})
+
+	t.Run("KeepsCommitsWithSameMessageButOtherRevision", func(t *testing.T) {
+
+		author := GitAuthor{Email: "jane@example.com"}
+		builds := []*Build{
+			{RepoBranch: "master", BuildVersion: "1.0.0", InsertedAt: time.Date(2018, 4, 17, 8, 0, 0, 0, time.UTC), Commits: []GitCommit{{Message: "fix: lint", Author: author, Revision: "a1"}}},
+			{RepoBranch: "master", BuildVersion: "1.0.1", InsertedAt: time.Date(2018, 4, 17, 8, 1, 0, 0, time.UTC), Commits: []GitCommit{{Message: "fix: lint", Author: author, Revision: "b2"}, {Message: "fix: lint", Author: author, Revision: "a1"}}},
+		}
+
+		// act
+		changelog, err := GetChangelog(builds, nil, getReleaseTargetsRelease("production", "", "1.0.1", StatusSucceeded, 10))
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 1, len(changelog.Sections)) {
+			assert.Equal(t, 2, len(changelog.Sections[0].Entries))
+		}
+	})
+
+	t.Run("ReturnsErrorIfNoBuildExistsForVersion", func(t *testing.T) {
+
+		builds := getChangelogBuilds()
+
+		// act
+		_, err := GetChangelog(builds, nil, getReleaseTargetsRelease("production", "", "2.0.0", StatusSucceeded, 10))
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestChangelogMarkdown(t *testing.T) {
+	t.Run("ListsBreakingChangesFirst", func(t *testing.T) {
+
+		changelog, _ := GetChangelog(getChangelogBuilds(), getReleaseTargetsRelease("production", "", "1.0.1", StatusSucceeded, 10), getReleaseTargetsRelease("production", "", "1.0.4", StatusSucceeded, 20))
+
+		// act
+		markdown := changelog.Markdown()
+
+		assert.Equal(t, "## 1.0.1...1.0.4\n\n"+
+			"### ⚠ Breaking Changes\n\n"+
+			"* **api:** targets are returned in manifest order\n\n"+
+			"### Features\n\n"+
+			"* add release targets\n"+
+			"* **api:** order release targets\n\n"+
+			"### Bug Fixes\n\n"+
+			"* handle missing manifest (#7)\n\n"+
+			"### Other Changes\n\n"+
+			"* Update readme\n", markdown)
+	})
+
+	t.Run("MarshalsToJSON", func(t *testing.T) {
+
+		changelog, _ := GetChangelog(getChangelogBuilds(), nil, getReleaseTargetsRelease("production", "", "1.0.1", StatusSucceeded, 10))
+
+		// act
+		bytes, err := json.Marshal(changelog)
+
+		assert.Nil(t, err)
+		assert.Equal(t, `{"toVersion":"1.0.1","sections":[{"type":"chore","title":"Chores","entries":[{"description":"bump dependencies","author":{"email":"jane@example.com","name":"Jane","username":"jane"},"buildVersion":"1.0.0"}]}]}`, string(bytes))
+	})
+}
+
+func getChangelogBuilds() []*Build {
+	author := GitAuthor{Email: "jane@example.com", Name: "Jane", Username: "jane"}
+	getBuild := func(version string, minute int, messages ...string) *Build {
+		branch := "master"
+		if strings.Contains(version, "-") {
+			branch = strings.SplitN(version, "-", 2)[1]
+		}
+		commits := []GitCommit{}
+		for _, m := range messages {
+			commits = append(commits, GitCommit{Message: m, Author: author})
+		}
+		return &Build{
+			RepoBranch:   branch,
+			BuildVersion: version,
+			BuildStatus:  StatusSucceeded,
+			Commits:      commits,
+			InsertedAt:   time.Date(2018, 4, 17, 8, minute, 0, 0, time.UTC),
+		}
+	}
+
+	return []*Build{
+		getBuild("1.0.3", 3, "fix: handle missing manifest\n\nFixes #7", "feat(api)!: order release targets\n\nBREAKING CHANGE: targets are returned in manifest order"),
+		getBuild("1.0.0", 0, "chore: bump dependencies"),
+		getBuild("1.0.1", 1, "chore: bump dependencies"),
+		getBuild("1.0.2", 2, "feat: add release targets"),
+		getBuild("1.0.3-feature", 3, "feat: unreleased feature"),
+		getBuild("1.0.4", 4, "Update readme\n\nWith release targets", "feat: add release targets"),
+		getBuild("1.0.5", 5, "feat: add changelog"),
+	}
+}
@@ -4,6 +4,8 @@ package contracts
 type GitCommit struct {
 	Message string    `json:"message"`
 	Author  GitAuthor `json:"author"`
+	// Revision is the commit hash, if known
+	Revision string `json:"revision,omitempty"`
 }
 
 // GitAuthor represents the author of a commmit
@@ -0,0 +1,243 @@
+package contracts
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// noreplyEmailRegex matches the noreply addresses git providers use to hide an author's email address, like
+// 12345+jane@users.noreply.github.com, jane@users.noreply.github.com or 12345-jane@users.noreply.gitlab.com
+var noreplyEmailRegex = regexp.MustCompile(`^(?:(\d+)[+-])?([^@+]+)@users\.noreply\.(github|gitlab)\.com$`)
+
+// CommitterResolver resolves git authors to users through the email addresses of their identities and, per provider,
+// the ids of their identities and usernames in their noreply addresses
+type CommitterResolver struct {
+	byEmail            map[string]*User
+	byProviderID       map[string]*User
+	byProviderUsername map[string]*User
+	// ambiguous holds the keys matching more than one user; those never resolve
+	ambiguous map[string]bool
+}
+
+// NewCommitterResolver returns a CommitterResolver for the users; an email address, id or username matching
+// identities of different users doesn't resolve to any of them
+func NewCommitterResolver(users []*User) *CommitterResolver {
+
+	resolver := &CommitterResolver{
+		byEmail:            map[string]*User{},
+		byProviderID:       map[string]*User{},
+		byProviderUsername: map[string]*User{},
+		ambiguous:          map[string]bool{},
+	}
+
+	for _, u := range users {
+		if u == nil {
+			continue
+		}
+		for _, i := range u.Identities {
+			if i == nil {
+				continue
+			}
+			if email := strings.ToLower(strings.TrimSpace(i.Email)); email != "" {
+				resolver.add(resolver.byEmail, "email:"+email, u)
+				// a user's noreply address also identifies them by username with the provider it belongs to
+				if provider, username, _, ok := getNoreplyUsername(email); ok {
+					resolver.add(resolver.byProviderUsername, getProviderKey("username", provider, username), u)
+				}
+			}
+			if provider := strings.ToLower(strings.TrimSpace(i.Provider)); provider != "" && strings.TrimSpace(i.ID) != "" {
+				resolver.add(resolver.byProviderID, getProviderKey("id", provider, i.ID), u)
+			}
+		}
+	}
+
+	return resolver
+}
+
+func (resolver *CommitterResolver) add(index map[string]*User, key string, user *User) {
+	if resolver.ambiguous[key] {
+		return
+	}
+	if existing, ok := index[key]; ok && existing != user {
+		delete(index, key)
+		resolver.ambiguous[key] = true
+		return
+	}
+	index[key] = user
+}
+
+// Resolve returns the user for the author of a commit in a repository from repoSource, like github.com; it matches by
+// email address, then by the author's username with the provider of the repository and finally by the username or
+// id in a noreply address with the provider of that address. It returns nil if no user or more than one user matches
+func (resolver *CommitterResolver) Resolve(author GitAuthor, repoSource string) *User {
+
+	email := strings.ToLower(strings.TrimSpace(author.Email))
+	if user, ok := resolver.byEmail["email:"+email]; ok && email != "" {
+		return user
+	}
+
+	if provider := getProviderForRepoSource(repoSource); provider != "" && strings.TrimSpace(author.Username) != "" {
+		if user, ok := resolver.byProviderUsername[getProviderKey("username", provider, author.Username)]; ok {
+			return user
+		}
+	}
+
+	if provider, username, id, ok := getNoreplyUsername(email); ok {
+		if user, ok := resolver.byProviderUsername[getProviderKey("username", provider, username)]; ok {
+			return user
+		}
+		if id != "" {
+			if user, ok := resolver.byProviderID[getProviderKey("id", provider, id)]; ok {
+				return user
+			}
+		}
+	}
+
+	return nil
+}
+
+// ResolveAll returns the distinct users the authors of commits in a repository from repoSource resolve to, in order
+// of first appearance; authors that don't resolve to a user are left out
+func (resolver *CommitterResolver) ResolveAll(authors []GitAuthor, repoSource string) []*User {
+
+	users := []*User{}
+	seen := map[*User]bool{}
+	for _, a := range authors {
+		user := resolver.Resolve(a, repoSource)
+		if user == nil || seen[user] {
+			continue
+		}
+		seen[user] = true
+		users = append(users, user)
+	}
+
+	return users
+}
+
+// GetDistinctAuthors returns the authors of the commits in a repository from repoSource, in order of first appearance,
+// with authors sharing an email address or a username with the same provider counted once. Usernames are only known
+// per provider, so an author's username matches their other addresses only through a noreply address of the provider
+// of the repository, like 12345+jane@users.noreply.github.com for github.com
+func GetDistinctAuthors(commits []GitCommit, repoSource string) []GitAuthor {
+
+	authors := []GitAuthor{}
+	seen := map[string]bool{}
+	for _, c := range commits {
+		keys := getAuthorKeys(c.Author, repoSource)
+		if len(keys) == 0 {
+			continue
+		}
+
+		duplicate := false
+		for _, k := range keys {
+			if seen[k] {
+				duplicate = true
+			}
+		}
+		for _, k := range keys {
+			seen[k] = true
+		}
+		if !duplicate {
+			authors = append(authors, c.Author)
+		}
+	}
+
+	return authors
+}
+
+// GetRecentCommitters returns the email addresses of the distinct authors of the commits of the builds, most recent
+// build first, up to limit; authors resolving to a user are listed with the user's email address so aliases of the
+// same user are listed once. The resolver can be nil to only deduplicate by author, limit 0 means no limit
+func GetRecentCommitters(builds []*Build, resolver *CommitterResolver, limit int) []string {
+
+	sortedBuilds := make([]*Build, 0, len(builds))
+	for _, b := range builds {
+		if b != nil {
+			sortedBuilds = append(sortedBuilds, b)
+		}
+	}
+	sort.SliceStable(sortedBuilds, func(i, j int) bool {
+		return sortedBuilds[i].InsertedAt.After(sortedBuilds[j].InsertedAt)
+	})
+
+	// builds of a single pipeline share the repository source, which determines the provider of author usernames
+	repoSource := ""
+	commits := []GitCommit{}
+	for _, b := range sortedBuilds {
+		if repoSource == "" {
+			repoSource = b.RepoSource
+		}
+		commits = append(commits, b.Commits...)
+	}
+
+	committers := []string{}
+	seen := map[string]bool{}
+	for _, a := range GetDistinctAuthors(commits, repoSource) {
+		email := a.Email
+		if resolver != nil {
+			if user := resolver.Resolve(a, repoSource); user != nil && user.GetEmail() != "" {
+				email = user.GetEmail()
+			}
+		}
+		if email == "" || seen[strings.ToLower(email)] {
+			continue
+		}
+		seen[strings.ToLower(email)] = true
+
+		committers = append(committers, email)
+		if limit > 0 && len(committers) >= limit {
+			break
+		}
+	}
+
+	return committers
+}
+
+// SetRecentCommitters sets the recent committers of the pipeline from the commits of its builds
+func (pipeline *Pipeline) SetRecentCommitters(builds []*Build, resolver *CommitterResolver, limit int) {
+	pipeline.RecentCommitters = GetRecentCommitters(builds, resolver, limit)
+}
+
+// getNoreplyUsername returns the provider, username and, if present, the numeric id in a noreply address
+func getNoreplyUsername(email string) (provider, username, id string, ok bool) {
+	matches := noreplyEmailRegex.FindStringSubmatch(strings.ToLower(strings.TrimSpace(email)))
+	if matches == nil {
+		return "", "", "", false
+	}
+
+	return matches[3], matches[2], matches[1], true
+}
+
+// getProviderForRepoSource returns the identity provider for a repository source, like github for github.com
+func getProviderForRepoSource(repoSource string) string {
+	host := strings.ToLower(strings.TrimSpace(repoSource))
+	if host == "" {
+		return ""
+	}
+	provider, _, _ := strings.Cut(host, ".")
+
+	return provider
+}
+
+// getProviderKey returns the index key for an id or username of an identity with the provider
+func getProviderKey(kind, provider, value string) string {
+	return kind + ":" + strings.ToLower(strings.TrimSpace(provider)) + "/" + strings.ToLower(strings.TrimSpace(value))
+}
+
+// getAuthorKeys returns the keys identifying the author of a commit in a repository from repoSource, in lowercase and
+// prefixed by their kind; usernames are scoped to their provider and left out if the provider is unknown
+func getAuthorKeys(author GitAuthor, repoSource string) []string {
+	keys := []string{}
+	if email := strings.ToLower(strings.TrimSpace(author.Email)); email != "" {
+		keys = append(keys, "email:"+email)
+		if provider, username, _, ok := getNoreplyUsername(email); ok {
+			keys = append(keys, getProviderKey("username", provider, username))
+		}
+	}
+	if provider := getProviderForRepoSource(repoSource); provider != "" && strings.TrimSpace(author.Username) != "" {
+		keys = append(keys, getProviderKey("username", provider, author.Username))
+	}
+
+	return keys
+}
@@ -0,0 +1,171 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitterResolverResolve(t *testing.T) {
+	t.Run("ResolvesByEmailUsernameAndNoreplyAlias", func(t *testing.T) {
+
+		users := getCommitterUsers()
+		resolver := NewCommitterResolver(users)
+
+		// act
+		byEmail := resolver.Resolve(GitAuthor{Email: "Jane@Example.com"}, "github.com")
+		byUsername := resolver.Resolve(GitAuthor{Email: "jane@laptop.local", Username: "JaneDoe"}, "github.com")
+		byNoreplyUsername := resolver.Resolve(GitAuthor{Email: "janedoe@users.noreply.github.com"}, "bitbucket.org")
+		byNoreplyID := resolver.Resolve(GitAuthor{Email: "12345+john-renamed@users.noreply.github.com"}, "github.com")
+		byGitlabNoreply := resolver.Resolve(GitAuthor{Email: "555-johnsmith@users.noreply.gitlab.com"}, "gitlab.com")
+		unknown := resolver.Resolve(GitAuthor{Email: "someone@example.com", Username: "someone"}, "github.com")
+
+		assert.Equal(t, users[0], byEmail)
+		assert.Equal(t, users[0], byUsername)
+		assert.Equal(t, users[0], byNoreplyUsername)
+		assert.Equal(t, users[1], byNoreplyID)
+		assert.Equal(t, users[1], byGitlabNoreply)
+		assert.Nil(t, unknown)
+	})
+
+	t.Run("DoesNotResolveByDisplayNameOrUsernameOfOtherProvider", func(t *testing.T) {
+
+		users := append(getCommitterUsers(), &User{
+			ID: "3",
+			Identities: []*UserIdentity{
+				{Provider: "google", ID: "12345", Email: "bob@example.com", Name: "bob"},
+			},
+		})
+		resolver := NewCommitterResolver(users)
+
+		// act
+		byDisplayName := resolver.Resolve(GitAuthor{Email: "bob@laptop.local", Username: "bob"}, "github.com")
+		byUsernameOfOtherProvider := resolver.Resolve(GitAuthor{Email: "jane@laptop.local", Username: "janedoe"}, "gitlab.com")
+		byNoreplyID := resolver.Resolve(GitAuthor{Email: "12345+someone@users.noreply.github.com"}, "github.com")
+
+		assert.Nil(t, byDisplayName)
+		assert.Nil(t, byUsernameOfOtherProvider)
+		assert.Equal(t, users[1], byNoreplyID)
+	})
+
+	t.Run("DoesNotResolveAmbiguousEmail", func(t *testing.T) {
+
+		users := append(getCommitterUsers(), &User{
+			ID: "3",
+			Identities: []*UserIdentity{
+				{Provider: "google", Email: "jane@example.com"},
+			},
+		})
+		resolver := NewCommitterResolver(users)
+
+		// act
+		user := resolver.Resolve(GitAuthor{Email: "jane@example.com"}, "github.com")
+
+		assert.Nil(t, user)
+	})
+
+	t.Run("ResolveAllReturnsDistinctUsers", func(t *testing.T) {
+
+		users := getCommitterUsers()
+		resolver := NewCommitterResolver(users)
+
+		// act
+		resolvedUsers := resolver.ResolveAll([]GitAuthor{{Email: "john@example.com"}, {Email: "unknown@example.com"}, {Username: "janedoe"}, {Email: "12345+john@users.noreply.github.com"}}, "github.com")
+
+		assert.Equal(t, []*User{users[1], users[0]}, resolvedUsers)
+	})
+}
+
+func TestGetDistinctAuthors(t *testing.T) {
+	t.Run("DeduplicatesByEmailUsernameAndNoreplyAlias", func(t *testing.T) {
+
+		commits := []GitCommit{
+			{Author: GitAuthor{Email: "jane@example.com", Username: "janedoe"}},
+			{Author: GitAuthor{Email: "JANE@example.com"}},
+			{Author: GitAuthor{Email: "98765+janedoe@users.noreply.github.com"}},
+			{Author: GitAuthor{Email: "john@example.com"}},
+			{Author: GitAuthor{}},
+		}
+
+		// act
+		authors := GetDistinctAuthors(commits, "github.com")
+
+		assert.Equal(t, []GitAuthor{{Email: "jane@example.com", Username: "janedoe"}, {Email: "john@example.com"}}, authors)
+	})
+
+	t.Run("KeepsSameUsernameWithOtherProviderApart", func(t *testing.T) {
+
+		commits := []GitCommit{
+			{Author: GitAuthor{Email: "jane@example.com", Username: "janedoe"}},
+			{Author: GitAuthor{Email: "98765-janedoe@users.noreply.gitlab.com"}},
+		}
+
+		// act
+		authors := GetDistinctAuthors(commits, "github.com")
+
+		assert.Equal(t, []GitAuthor{{Email: "jane@example.com", Username: "janedoe"}, {Email: "98765-janedoe@users.noreply.gitlab.com"}}, authors)
+	})
+
+	t.Run("IgnoresUsernameWithoutRepoSource", func(t *testing.T) {
+
+		commits := []GitCommit{
+			{Author: GitAuthor{Email: "jane@example.com", Username: "janedoe"}},
+			{Author: GitAuthor{Email: "jane@work.example.com", Username: "janedoe"}},
+		}
+
+		// act
+		authors := GetDistinctAuthors(commits, "")
+
+		assert.Equal(t, 2, len(authors))
+	})
+}
+
+func TestGetRecentCommitters(t *testing.T) {
+	t.Run("ReturnsResolvedEmailsMostRecentFirst", func(t *testing.T) {
+
+		builds := []*Build{
+			{InsertedAt: time.Date(2018, 4, 17, 8, 0, 0, 0, time.UTC), Commits: []GitCommit{{Author: GitAuthor{Email: "jane@example.com"}}}},
+			{InsertedAt: time.Date(2018, 4, 17, 8, 2, 0, 0, time.UTC), Commits: []GitCommit{{Author: GitAuthor{Email: "12345+john@users.noreply.github.com"}}}},
+			{InsertedAt: time.Date(2018, 4, 17, 8, 1, 0, 0, time.UTC), Commits: []GitCommit{{Author: GitAuthor{Email: "someone@example.com"}}, {Author: GitAuthor{Email: "john@example.com"}}}},
+		}
+		pipeline := &Pipeline{}
+
+		// act
+		pipeline.SetRecentCommitters(builds, NewCommitterResolver(getCommitterUsers()), 0)
+
+		assert.Equal(t, []string{"john@example.com", "someone@example.com", "jane@example.com"}, pipeline.RecentCommitters)
+	})
+
+	t.Run("LimitsNumberOfCommittersWithoutResolver", func(t *testing.T) {
+
+		builds := []*Build{
+			{Commits: []GitCommit{{Author: GitAuthor{Email: "jane@example.com"}}, {Author: GitAuthor{Email: "john@example.com"}}}},
+		}
+
+		// act
+		committers := GetRecentCommitters(builds, nil, 1)
+
+		assert.Equal(t, []string{"jane@example.com"}, committers)
+	})
+}
+
+func getCommitterUsers() []*User {
+	return []*User{
+		{
+			ID: "1",
+			Identities: []*UserIdentity{
+				{Provider: "google", Email: "jane@example.com", Name: "Jane Doe"},
+				{Provider: "github", ID: "98765", Email: "98765+janedoe@users.noreply.github.com", Name: "Jane"},
+			},
+		},
+		{
+			ID: "2",
+			Identities: []*UserIdentity{
+				{Provider: "google", Email: "john@example.com", Name: "John Smith"},
+				{Provider: "github", ID: "12345", Name: "John"},
+				{Provider: "gitlab", Email: "555-johnsmith@users.noreply.gitlab.com"},
+			},
+		},
+	}
+}
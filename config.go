@@ -292,14 +292,17 @@ func FilterCredentialsByBranchesAllowList(credentials []*CredentialConfig, branc
 // IsAllowedPipelineForCredential returns true if AllowedPipelines is empty or matches the pipelines full path
 func IsAllowedPipelineForCredential(credential CredentialConfig, fullRepositoryPath string) bool {
 
-	if credential.AllowedPipelines == "" {
-		return true
-	}
+	return isAllowedPipeline(credential.AllowedPipelines, fullRepositoryPath)
+}
 
-	pattern := fmt.Sprintf("^(%v)$", strings.TrimSpace(credential.AllowedPipelines))
-	isMatch, _ := regexp.Match(pattern, []byte(fullRepositoryPath))
+// FilterCredentialsByPipelinesAllowListForRepoRef returns the list of credentials filtered by the AllowedPipelines property on the credentials
+func FilterCredentialsByPipelinesAllowListForRepoRef(credentials []*CredentialConfig, repoRef RepoRef) []*CredentialConfig {
+	return FilterCredentialsByPipelinesAllowList(credentials, repoRef.String())
+}
 
-	return isMatch
+// IsAllowedRepoRefForCredential returns true if AllowedPipelines is empty or matches the full path of the repository
+func IsAllowedRepoRefForCredential(credential CredentialConfig, repoRef RepoRef) bool {
+	return IsAllowedPipelineForCredential(credential, repoRef.String())
 }
 
 // IsAllowedBranchForCredential returns true if AllowedBranches is empty or matches the build/release job branch
@@ -331,11 +334,27 @@ func FilterTrustedImagesByPipelinesAllowList(trustedImages []*TrustedImageConfig
 // IsAllowedPipelineForTrustedImage returns true if AllowedPipelines is empty or matches the pipelines full path
 func IsAllowedPipelineForTrustedImage(trustedImage TrustedImageConfig, fullRepositoryPath string) bool {
 
-	if trustedImage.AllowedPipelines == "" {
+	return isAllowedPipeline(trustedImage.AllowedPipelines, fullRepositoryPath)
+}
+
+// FilterTrustedImagesByPipelinesAllowListForRepoRef returns the list of trusted images filtered by the AllowedTrustedPipelines property on the trusted images
+func FilterTrustedImagesByPipelinesAllowListForRepoRef(trustedImages []*TrustedImageConfig, repoRef RepoRef) []*TrustedImageConfig {
+	return FilterTrustedImagesByPipelinesAllowList(trustedImages, repoRef.String())
+}
+
+// IsAllowedRepoRefForTrustedImage returns true if AllowedPipelines is empty or matches the full path of the repository
+func IsAllowedRepoRefForTrustedImage(trustedImage TrustedImageConfig, repoRef RepoRef) bool {
+	return IsAllowedPipelineForTrustedImage(trustedImage, repoRef.String())
+}
+
+// isAllowedPipeline returns true if the allowed pipelines pattern is empty or matches the full repository path
+func isAllowedPipeline(allowedPipelines, fullRepositoryPath string) bool {
+
+	if allowedPipelines == "" {
 		return true
 	}
 
-	pattern := fmt.Sprintf("^(%v)$", strings.TrimSpace(trustedImage.AllowedPipelines))
+	pattern := fmt.Sprintf("^(%v)$", strings.TrimSpace(allowedPipelines))
 	isMatch, _ := regexp.Match(pattern, []byte(fullRepositoryPath))
 
 	return isMatch
@@ -449,6 +468,11 @@ func FilterTrustedImages(trustedImages []*TrustedImageConfig, stages []*manifest
 	return filteredImages
 }
 
+// FilterTrustedImagesForRepoRef returns only trusted images used in the stages and allowed for the repository
+func FilterTrustedImagesForRepoRef(trustedImages []*TrustedImageConfig, stages []*manifest.ZiplineeStage, repoRef RepoRef) []*TrustedImageConfig {
+	return FilterTrustedImages(trustedImages, stages, repoRef.String())
+}
+
 // FilterCredentials returns only credentials used by the trusted images
 func FilterCredentials(credentials []*CredentialConfig, trustedImages []*TrustedImageConfig, fullRepositoryPath, branch string) []*CredentialConfig {
 
@@ -469,6 +493,11 @@ func FilterCredentials(credentials []*CredentialConfig, trustedImages []*Trusted
 	return filteredCredentials
 }
 
+// FilterCredentialsForRepoRef returns only credentials used by the trusted images and allowed for the repository and branch
+func FilterCredentialsForRepoRef(credentials []*CredentialConfig, trustedImages []*TrustedImageConfig, repoRef RepoRef, branch string) []*CredentialConfig {
+	return FilterCredentials(credentials, trustedImages, repoRef.String(), branch)
+}
+
 // AddCredentialsIfNotPresent adds new credentials to source credentials if they're not present yet
 func AddCredentialsIfNotPresent(sourceCredentials []*CredentialConfig, newCredentials []*CredentialConfig) []*CredentialConfig {
 
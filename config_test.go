@@ -915,6 +915,40 @@ func TestFilterCredentials(t *testing.T) {
 		assert.Equal(t, "gke-b", filteredCredentials[0].Name)
 		assert.Equal(t, "kubernetes-engine", filteredCredentials[0].Type)
 	})
+
+	t.Run("FiltersByAllowedPipelinesForRepoRefOfBuild", func(t *testing.T) {
+
+		credentials := []*CredentialConfig{
+			&CredentialConfig{
+				Name:             "gke-a",
+				Type:             "kubernetes-engine",
+				AllowedPipelines: "github.com/ziplineeci/ziplinee-ci-api",
+			},
+			&CredentialConfig{
+				Name:             "gke-b",
+				Type:             "kubernetes-engine",
+				AllowedPipelines: "github.com/ziplineeci/ziplinee-ci-contracts",
+			},
+		}
+		trustedImages := []*TrustedImageConfig{
+			&TrustedImageConfig{
+				ImagePath: "extensions/gke",
+				InjectedCredentialTypes: []string{
+					"kubernetes-engine",
+				},
+			},
+		}
+		build := &Build{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-contracts"}
+
+		// act
+		filteredCredentials := FilterCredentialsForRepoRef(credentials, trustedImages, build.GetRepoRef(), "master")
+
+		if assert.Equal(t, 1, len(filteredCredentials)) {
+			assert.Equal(t, "gke-b", filteredCredentials[0].Name)
+		}
+		assert.True(t, IsAllowedRepoRefForCredential(*credentials[1], build.GetRepoRef()))
+		assert.False(t, IsAllowedRepoRefForTrustedImage(TrustedImageConfig{AllowedPipelines: "github.com/ziplineeci/ziplinee-ci-api"}, build.GetRepoRef()))
+	})
 }
 
 func TestValidate(t *testing.T) {
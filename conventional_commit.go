@@ -0,0 +1,141 @@
+package contracts
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	conventionalCommitHeaderRegex = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: +(\S.*)$`)
+	conventionalCommitFooterRegex = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z0-9-]*)(: | #)(.*)$`)
+	issueReferenceRegex           = regexp.MustCompile(`(?:[\w.-]+/[\w.-]+)?#\d+|\b[A-Z][A-Z0-9]+-\d+\b`)
+)
+
+// issueReferenceFooterTokens are the footer tokens, in lowercase, whose values are read as issue references
+var issueReferenceFooterTokens = map[string]bool{
+	"closes":   true,
+	"close":    true,
+	"fixes":    true,
+	"fix":      true,
+	"resolves": true,
+	"resolve":  true,
+	"refs":     true,
+	"ref":      true,
+	"issue":    true,
+}
+
+// ConventionalCommit holds the parts of a commit message following the conventional commits specification, like feat(api)!: add endpoint
+type ConventionalCommit struct {
+	Type        string         `json:"type"`
+	Scope       string         `json:"scope,omitempty"`
+	Breaking    bool           `json:"breaking,omitempty"`
+	Description string         `json:"description"`
+	Body        string         `json:"body,omitempty"`
+	Footers     []CommitFooter `json:"footers,omitempty"`
+	// BreakingChange holds the value of the BREAKING CHANGE footer, if any
+	BreakingChange  string   `json:"breakingChange,omitempty"`
+	IssueReferences []string `json:"issueReferences,omitempty"`
+}
+
+// CommitFooter is a single trailer at the end of a commit message, like Closes: #123 or Reviewed-by: someone
+type CommitFooter struct {
+	Token string `json:"token"`
+	Value string `json:"value"`
+}
+
+// ParseConventionalCommit parses the message of the commit; it returns false if the message doesn't follow the conventional commits specification
+func (commit *GitCommit) ParseConventionalCommit() (ConventionalCommit, bool) {
+	return ParseConventionalCommit(commit.Message)
+}
+
+// ParseConventionalCommit parses a commit message into its conventional commit parts; it returns false if the first line isn't a conventional commit header
+func ParseConventionalCommit(message string) (ConventionalCommit, bool) {
+
+	lines := strings.Split(strings.ReplaceAll(strings.TrimSpace(message), "\r\n", "\n"), "\n")
+
+	matches := conventionalCommitHeaderRegex.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if matches == nil {
+		return ConventionalCommit{}, false
+	}
+
+	commit := ConventionalCommit{
+		Type:        strings.ToLower(matches[1]),
+		Scope:       strings.TrimSpace(matches[2]),
+		Breaking:    matches[3] == "!",
+		Description: strings.TrimSpace(matches[4]),
+	}
+
+	// the footers are the last paragraph if its first line is a footer
+	paragraphs := splitCommitParagraphs(lines[1:])
+	if len(paragraphs) > 0 {
+		last := paragraphs[len(paragraphs)-1]
+		if conventionalCommitFooterRegex.MatchString(last[0]) {
+			commit.Footers = parseCommitFooters(last)
+			paragraphs = paragraphs[:len(paragraphs)-1]
+		}
+	}
+
+	body := make([]string, 0, len(paragraphs))
+	for _, p := range paragraphs {
+		body = append(body, strings.Join(p, "\n"))
+	}
+	commit.Body = strings.Join(body, "\n\n")
+
+	for _, f := range commit.Footers {
+		switch {
+		case f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE":
+			commit.Breaking = true
+			commit.BreakingChange = f.Value
+		case issueReferenceFooterTokens[strings.ToLower(f.Token)]:
+			commit.IssueReferences = append(commit.IssueReferences, issueReferenceRegex.FindAllString(f.Value, -1)...)
+		}
+	}
+
+	return commit, true
+}
+
+// splitCommitParagraphs splits lines into paragraphs separated by one or more empty lines
+func splitCommitParagraphs(lines []string) [][]string {
+	paragraphs := [][]string{}
+	paragraph := []string{}
+	for _, l := range lines {
+		l = strings.TrimRight(l, " \t")
+		if l == "" {
+			if len(paragraph) > 0 {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = []string{}
+			}
+			continue
+		}
+		paragraph = append(paragraph, l)
+	}
+	if len(paragraph) > 0 {
+		paragraphs = append(paragraphs, paragraph)
+	}
+
+	return paragraphs
+}
+
+// parseCommitFooters parses footer lines, appending lines that don't start a new footer to the value of the previous one
+func parseCommitFooters(lines []string) []CommitFooter {
+	footers := []CommitFooter{}
+	for _, l := range lines {
+		matches := conventionalCommitFooterRegex.FindStringSubmatch(l)
+		if matches == nil {
+			footers[len(footers)-1].Value += "\n" + l
+			continue
+		}
+
+		value := strings.TrimSpace(matches[3])
+		if matches[2] == " #" {
+			// keep the hash of a footer like Fixes #123 as part of the issue reference
+			value = "#" + value
+		}
+		footers = append(footers, CommitFooter{
+			Token: matches[1],
+			Value: value,
+		})
+	}
+
+	return footers
+}
@@ -0,0 +1,57 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	t.Run("ParsesHeaderBodyAndFooters", func(t *testing.T) {
+
+		message := "feat(api)!: add streaming endpoints\n\nAllows tailing logs over grpc.\n\nReviewed-by: jane\nBREAKING CHANGE: the tail endpoint\nno longer returns json\nCloses #12, #13\nRefs: ZCI-45"
+
+		// act
+		commit, ok := ParseConventionalCommit(message)
+
+		assert.True(t, ok)
+		assert.Equal(t, "feat", commit.Type)
+		assert.Equal(t, "api", commit.Scope)
+		assert.True(t, commit.Breaking)
+		assert.Equal(t, "add streaming endpoints", commit.Description)
+		assert.Equal(t, "Allows tailing logs over grpc.", commit.Body)
+		assert.Equal(t, []CommitFooter{
+			{Token: "Reviewed-by", Value: "jane"},
+			{Token: "BREAKING CHANGE", Value: "the tail endpoint\nno longer returns json"},
+			{Token: "Closes", Value: "#12, #13"},
+			{Token: "Refs", Value: "ZCI-45"},
+		}, commit.Footers)
+		assert.Equal(t, "the tail endpoint\nno longer returns json", commit.BreakingChange)
+		assert.Equal(t, []string{"#12", "#13", "ZCI-45"}, commit.IssueReferences)
+	})
+
+	t.Run("ParsesHeaderWithoutScope", func(t *testing.T) {
+
+		commit := GitCommit{Message: "Fix: handle empty manifest"}
+
+		// act
+		conventionalCommit, ok := commit.ParseConventionalCommit()
+
+		assert.True(t, ok)
+		assert.Equal(t, "fix", conventionalCommit.Type)
+		assert.Equal(t, "", conventionalCommit.Scope)
+		assert.False(t, conventionalCommit.Breaking)
+		assert.Equal(t, "handle empty manifest", conventionalCommit.Description)
+		assert.Equal(t, 0, len(conventionalCommit.Footers))
+	})
+
+	t.Run("ReturnsFalseForNonConventionalMessage", func(t *testing.T) {
+
+		for _, message := range []string{"Merge branch 'main'", "update readme", "feat(api: missing parenthesis", "feat:"} {
+			// act
+			_, ok := ParseConventionalCommit(message)
+
+			assert.False(t, ok, message)
+		}
+	})
+}
@@ -0,0 +1,424 @@
+package contracts
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultDoraMetricsPeriod is the length of a bucket if no period is set
+	DefaultDoraMetricsPeriod = 7 * 24 * time.Hour
+	// MaxDoraMetricsBuckets caps the number of buckets; for longer time ranges only the most recent periods are kept
+	MaxDoraMetricsBuckets = 1000
+)
+
+// DoraMetricsOptions controls the time range and bucket length of dora metrics
+type DoraMetricsOptions struct {
+	// From defaults to the time the first release finished
+	From time.Time `json:"from,omitempty"`
+	// To defaults to the time the last release finished
+	To time.Time `json:"to,omitempty"`
+	// Period is the length of each bucket; defaults to DefaultDoraMetricsPeriod
+	Period time.Duration `json:"period,omitempty"`
+}
+
+// PipelineDoraMetrics holds the dora metrics for a pipeline as a whole and for each of its release targets and actions
+type PipelineDoraMetrics struct {
+	RepoSource     string        `json:"repoSource,omitempty"`
+	RepoOwner      string        `json:"repoOwner,omitempty"`
+	RepoName       string        `json:"repoName,omitempty"`
+	From           time.Time     `json:"from"`
+	To             time.Time     `json:"to"`
+	Period         time.Duration `json:"period"`
+	Pipeline       DoraMetrics   `json:"pipeline"`
+	ReleaseTargets []DoraMetrics `json:"releaseTargets"`
+}
+
+// DoraMetrics holds the dora metrics over the full time range and per period for a pipeline or a single release target and action
+type DoraMetrics struct {
+	ReleaseTarget string              `json:"releaseTarget,omitempty"`
+	Action        string              `json:"action,omitempty"`
+	Summary       DoraMetricsBucket   `json:"summary"`
+	Buckets       []DoraMetricsBucket `json:"buckets"`
+}
+
+// DoraMetricsBucket holds the dora metrics for releases finished from start up to end
+type DoraMetricsBucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Deployments is the number of succeeded releases
+	Deployments       int `json:"deployments"`
+	FailedDeployments int `json:"failedDeployments"`
+	// DeploymentFrequency is the number of succeeded releases per day
+	DeploymentFrequency float64 `json:"deploymentFrequency"`
+	// ChangeFailureRate is the fraction of succeeded and failed releases that failed
+	ChangeFailureRate float64 `json:"changeFailureRate"`
+	// MedianLeadTime is the median time between the first build containing a commit and the succeeded release deploying it
+	MedianLeadTime time.Duration `json:"medianLeadTime,omitempty"`
+	Changes        int           `json:"changes"`
+	// MedianTimeToRestore is the median time between the first failed release in a row and the next succeeded release
+	MedianTimeToRestore time.Duration `json:"medianTimeToRestore,omitempty"`
+	Restores            int           `json:"restores"`
+}
+
+type doraEventType int
+
+const (
+	doraEventDeployment doraEventType = iota
+	doraEventFailure
+	doraEventLeadTime
+	doraEventRestore
+)
+
+type doraEvent struct {
+	eventType doraEventType
+	at        time.Time
+	duration  time.Duration
+	// commitKey identifies the commit of a lead time event
+	commitKey string
+}
+
+// GetDoraMetrics computes deployment frequency, lead time for changes, change failure rate and time to restore from the
+// builds and releases of a single pipeline; canceled and unfinished releases are ignored. The pipeline is the repository
+// of the first release, builds and releases of other repositories are left out; use GetDoraMetricsPerRepo for mixed
+// input. Since commits carry no timestamp the time a commit was first built is used as its commit time
+func GetDoraMetrics(builds []*Build, releases []*Release, options DoraMetricsOptions) PipelineDoraMetrics {
+
+	if options.Period <= 0 {
+		options.Period = DefaultDoraMetricsPeriod
+	}
+
+	var repoRef RepoRef
+	for _, r := range releases {
+		if r != nil {
+			repoRef = r.GetRepoRef().Normalize()
+			break
+		}
+	}
+
+	// oldest first, so a commit's lead time is measured from the first build containing it
+	sortedBuilds := make([]*Build, 0, len(builds))
+	for _, b := range builds {
+		if b != nil && b.GetRepoRef().Normalize() == repoRef {
+			sortedBuilds = append(sortedBuilds, b)
+		}
+	}
+	sort.SliceStable(sortedBuilds, func(i, j int) bool {
+		return sortedBuilds[i].InsertedAt.Before(sortedBuilds[j].InsertedAt)
+	})
+
+	// group finished releases per target and action, in the order they finished
+	type releaseTargetKey struct{ name, action string }
+	releasesPerTarget := map[releaseTargetKey][]*Release{}
+	finishedAt := map[*Release]time.Time{}
+	metrics := PipelineDoraMetrics{}
+	for _, r := range releases {
+		if r == nil || (r.ReleaseStatus != StatusSucceeded && r.ReleaseStatus != StatusFailed) {
+			continue
+		}
+		if r.GetRepoRef().Normalize() != repoRef {
+			continue
+		}
+		at := getReleaseFinishedAt(r)
+		if at.IsZero() {
+			continue
+		}
+		finishedAt[r] = at
+
+		key := releaseTargetKey{r.Name, r.Action}
+		releasesPerTarget[key] = append(releasesPerTarget[key], r)
+
+		if metrics.RepoSource == "" {
+			metrics.RepoSource, metrics.RepoOwner, metrics.RepoName = r.RepoSource, r.RepoOwner, r.RepoName
+		}
+		if metrics.From.IsZero() || at.Before(metrics.From) {
+			metrics.From = at
+		}
+		if at.After(metrics.To) {
+			metrics.To = at
+		}
+	}
+	if !options.From.IsZero() {
+		metrics.From = options.From
+	}
+	if !options.To.IsZero() {
+		metrics.To = options.To
+	}
+	metrics.Period = options.Period
+	metrics.ReleaseTargets = []DoraMetrics{}
+
+	// without releases and a start time there's no time range to bucket
+	if metrics.From.IsZero() {
+		metrics.Pipeline = DoraMetrics{Buckets: []DoraMetricsBucket{}}
+		return metrics
+	}
+
+	bucketCount := 1
+	if metrics.To.After(metrics.From) {
+		span := metrics.To.Sub(metrics.From)
+		bucketCount = int(span / metrics.Period)
+		if span%metrics.Period != 0 {
+			bucketCount++
+		}
+	}
+	if bucketCount > MaxDoraMetricsBuckets {
+		bucketCount = MaxDoraMetricsBuckets
+		metrics.From = metrics.To.Add(-time.Duration(bucketCount) * metrics.Period)
+	}
+
+	keys := make([]releaseTargetKey, 0, len(releasesPerTarget))
+	for k := range releasesPerTarget {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].action < keys[j].action
+	})
+
+	pipelineEvents := []doraEvent{}
+	for _, k := range keys {
+		targetReleases := releasesPerTarget[k]
+		sort.SliceStable(targetReleases, func(i, j int) bool {
+			return finishedAt[targetReleases[i]].Before(finishedAt[targetReleases[j]])
+		})
+
+		events := getReleaseTargetDoraEvents(sortedBuilds, targetReleases, finishedAt)
+		pipelineEvents = append(pipelineEvents, events...)
+
+		targetMetrics := getDoraMetricsForEvents(events, metrics.From, metrics.To, metrics.Period, bucketCount)
+		targetMetrics.ReleaseTarget = k.name
+		targetMetrics.Action = k.action
+		metrics.ReleaseTargets = append(metrics.ReleaseTargets, targetMetrics)
+	}
+
+	metrics.Pipeline = getDoraMetricsForEvents(getPipelineDoraEvents(pipelineEvents), metrics.From, metrics.To, metrics.Period, bucketCount)
+
+	return metrics
+}
+
+// GetDoraMetricsPerRepo groups builds and releases by repository and computes the dora metrics for each repository with
+// releases, ordered by repository
+func GetDoraMetricsPerRepo(builds []*Build, releases []*Release, options DoraMetricsOptions) []PipelineDoraMetrics {
+
+	buildsPerRepo := map[RepoRef][]*Build{}
+	for _, b := range builds {
+		if b != nil {
+			repoRef := b.GetRepoRef().Normalize()
+			buildsPerRepo[repoRef] = append(buildsPerRepo[repoRef], b)
+		}
+	}
+	releasesPerRepo := map[RepoRef][]*Release{}
+	for _, r := range releases {
+		if r != nil {
+			repoRef := r.GetRepoRef().Normalize()
+			releasesPerRepo[repoRef] = append(releasesPerRepo[repoRef], r)
+		}
+	}
+
+	repoRefs := make([]RepoRef, 0, len(releasesPerRepo))
+	for repoRef := range releasesPerRepo {
+		repoRefs = append(repoRefs, repoRef)
+	}
+	sort.Slice(repoRefs, func(i, j int) bool {
+		return repoRefs[i].String() < repoRefs[j].String()
+	})
+
+	metrics := make([]PipelineDoraMetrics, 0, len(repoRefs))
+	for _, repoRef := range repoRefs {
+		metrics = append(metrics, GetDoraMetrics(buildsPerRepo[repoRef], releasesPerRepo[repoRef], options))
+	}
+
+	return metrics
+}
+
+// getPipelineDoraEvents combines the events of all release targets, keeping a single lead time event per commit for
+// the first succeeded release deploying it, so commits released to several targets are counted once
+func getPipelineDoraEvents(events []doraEvent) []doraEvent {
+
+	firstLeadTime := map[string]int{}
+	pipelineEvents := make([]doraEvent, 0, len(events))
+	for _, e := range events {
+		if e.eventType != doraEventLeadTime {
+			pipelineEvents = append(pipelineEvents, e)
+			continue
+		}
+		if i, ok := firstLeadTime[e.commitKey]; ok {
+			if e.at.Before(pipelineEvents[i].at) {
+				pipelineEvents[i] = e
+			}
+			continue
+		}
+		firstLeadTime[e.commitKey] = len(pipelineEvents)
+		pipelineEvents = append(pipelineEvents, e)
+	}
+
+	return pipelineEvents
+}
+
+// getReleaseTargetDoraEvents returns the events for the releases of a single target and action, which need to be sorted by the time they finished
+func getReleaseTargetDoraEvents(sortedBuilds []*Build, releases []*Release, finishedAt map[*Release]time.Time) []doraEvent {
+
+	events := []doraEvent{}
+
+	var previousSucceeded *Release
+	var failingSince *time.Time
+	for _, r := range releases {
+		at := finishedAt[r]
+
+		if r.ReleaseStatus == StatusFailed {
+			events = append(events, doraEvent{eventType: doraEventFailure, at: at})
+			if failingSince == nil {
+				failingSince = &at
+			}
+			continue
+		}
+
+		events = append(events, doraEvent{eventType: doraEventDeployment, at: at})
+		if failingSince != nil {
+			events = append(events, doraEvent{eventType: doraEventRestore, at: at, duration: getElapsedDuration(failingSince, at)})
+			failingSince = nil
+		}
+
+		previousVersion := ""
+		if previousSucceeded != nil {
+			previousVersion = previousSucceeded.ReleaseVersion
+		}
+		if previousVersion != r.ReleaseVersion {
+			events = append(events, getLeadTimeEvents(sortedBuilds, previousVersion, r.ReleaseVersion, at)...)
+		}
+		previousSucceeded = r
+	}
+
+	return events
+}
+
+// getLeadTimeEvents returns a lead time event for every distinct commit in the builds on the branch of the build for the version,
+// after the build for the previous version up to and including the build for the version; without a previous version
+// only the build for the version is used
+func getLeadTimeEvents(sortedBuilds []*Build, previousVersion, version string, deployedAt time.Time) []doraEvent {
+
+	leadTimes := []doraEvent{}
+
+	toBuild := getBuildForVersion(sortedBuilds, version)
+	if toBuild == nil {
+		return leadTimes
+	}
+	var fromBuild *Build
+	if previousVersion != "" {
+		fromBuild = getBuildForVersion(sortedBuilds, previousVersion)
+	}
+
+	seen := map[string]bool{}
+	for _, b := range sortedBuilds {
+		if b.InsertedAt.After(toBuild.InsertedAt) {
+			break
+		}
+		if fromBuild != nil && !b.InsertedAt.After(fromBuild.InsertedAt) {
+			continue
+		}
+		if fromBuild == nil && b != toBuild {
+			continue
+		}
+		// builds of other branches in the same time window didn't end up in the deployed version
+		if b.RepoBranch != toBuild.RepoBranch {
+			continue
+		}
+
+		for _, c := range b.Commits {
+			key := getCommitKey(c)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			leadTimes = append(leadTimes, doraEvent{eventType: doraEventLeadTime, at: deployedAt, duration: getElapsedDuration(&b.InsertedAt, deployedAt), commitKey: key})
+		}
+	}
+
+	return leadTimes
+}
+
+// getDoraMetricsForEvents buckets the events from from up to and including to
+func getDoraMetricsForEvents(events []doraEvent, from, to time.Time, period time.Duration, bucketCount int) DoraMetrics {
+
+	bucketEvents := make([][]doraEvent, bucketCount)
+	summaryEvents := []doraEvent{}
+	for _, e := range events {
+		if e.at.Before(from) || e.at.After(to) {
+			continue
+		}
+		i := int(e.at.Sub(from) / period)
+		if i >= bucketCount {
+			i = bucketCount - 1
+		}
+		bucketEvents[i] = append(bucketEvents[i], e)
+		summaryEvents = append(summaryEvents, e)
+	}
+
+	metrics := DoraMetrics{
+		Summary: getDoraMetricsBucket(summaryEvents, from, from.Add(time.Duration(bucketCount)*period)),
+		Buckets: make([]DoraMetricsBucket, 0, bucketCount),
+	}
+	for i, be := range bucketEvents {
+		start := from.Add(time.Duration(i) * period)
+		metrics.Buckets = append(metrics.Buckets, getDoraMetricsBucket(be, start, start.Add(period)))
+	}
+
+	return metrics
+}
+
+func getDoraMetricsBucket(events []doraEvent, start, end time.Time) DoraMetricsBucket {
+
+	bucket := DoraMetricsBucket{
+		Start: start,
+		End:   end,
+	}
+
+	leadTimes := []time.Duration{}
+	restoreTimes := []time.Duration{}
+	for _, e := range events {
+		switch e.eventType {
+		case doraEventDeployment:
+			bucket.Deployments++
+		case doraEventFailure:
+			bucket.FailedDeployments++
+		case doraEventLeadTime:
+			leadTimes = append(leadTimes, e.duration)
+		case doraEventRestore:
+			restoreTimes = append(restoreTimes, e.duration)
+		}
+	}
+
+	if days := end.Sub(start).Hours() / 24; days > 0 {
+		bucket.DeploymentFrequency = float64(bucket.Deployments) / days
+	}
+	if total := bucket.Deployments + bucket.FailedDeployments; total > 0 {
+		bucket.ChangeFailureRate = float64(bucket.FailedDeployments) / float64(total)
+	}
+	bucket.Changes = len(leadTimes)
+	bucket.MedianLeadTime = getPercentileDuration(leadTimes, 0.5)
+	bucket.Restores = len(restoreTimes)
+	bucket.MedianTimeToRestore = getPercentileDuration(restoreTimes, 0.5)
+
+	return bucket
+}
+
+// getReleaseFinishedAt returns the time the release finished from its start and duration, falling back to the time
+// it was last updated or inserted
+func getReleaseFinishedAt(release *Release) time.Time {
+	if release.Duration != nil {
+		if release.StartedAt != nil {
+			return release.StartedAt.Add(*release.Duration)
+		}
+		if release.InsertedAt != nil {
+			return release.InsertedAt.Add(*release.Duration)
+		}
+	}
+	if release.UpdatedAt != nil {
+		return *release.UpdatedAt
+	}
+
+	return getTimeOrZero(release.InsertedAt)
+}
@@ -0,0 +1,218 @@
+package contracts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDoraMetrics(t *testing.T) {
+	t.Run("ComputesMetricsPerReleaseTargetAndPeriod", func(t *testing.T) {
+
+		builds, releases := getDoraMetricsBuildsAndReleases()
+
+		// act
+		metrics := GetDoraMetrics(builds, releases, DoraMetricsOptions{
+			From:   getDoraMetricsTime(0),
+			To:     getDoraMetricsTime(48 * time.Hour),
+			Period: 24 * time.Hour,
+		})
+
+		assert.Equal(t, "github.com", metrics.RepoSource)
+		assert.Equal(t, "ziplineeci", metrics.RepoOwner)
+		assert.Equal(t, "ziplinee-ci-api", metrics.RepoName)
+		assert.Equal(t, 24*time.Hour, metrics.Period)
+		if assert.Equal(t, 2, len(metrics.ReleaseTargets)) {
+			production := metrics.ReleaseTargets[0]
+			assert.Equal(t, "production", production.ReleaseTarget)
+			assert.Equal(t, 3, production.Summary.Deployments)
+			assert.Equal(t, 1, production.Summary.FailedDeployments)
+			assert.Equal(t, 0.25, production.Summary.ChangeFailureRate)
+			assert.Equal(t, 1.5, production.Summary.DeploymentFrequency)
+			assert.Equal(t, 1, production.Summary.Restores)
+			assert.Equal(t, 2*time.Hour, production.Summary.MedianTimeToRestore)
+			assert.Equal(t, 4, production.Summary.Changes)
+
+			if assert.Equal(t, 2, len(production.Buckets)) {
+				assert.Equal(t, getDoraMetricsTime(0), production.Buckets[0].Start)
+				assert.Equal(t, getDoraMetricsTime(24*time.Hour), production.Buckets[0].End)
+				assert.Equal(t, 1, production.Buckets[0].Deployments)
+				assert.Equal(t, 1, production.Buckets[0].Changes)
+				assert.Equal(t, time.Hour, production.Buckets[0].MedianLeadTime)
+				assert.Equal(t, 2, production.Buckets[1].Deployments)
+				assert.Equal(t, 1, production.Buckets[1].FailedDeployments)
+				// 2 commits built at 25h and 26h, 1 commit built at 27h; deployed at 30h and 32h
+				assert.Equal(t, 3, production.Buckets[1].Changes)
+				assert.Equal(t, 5*time.Hour, production.Buckets[1].MedianLeadTime)
+			}
+
+			staging := metrics.ReleaseTargets[1]
+			assert.Equal(t, "staging", staging.ReleaseTarget)
+			assert.Equal(t, 1, staging.Summary.Deployments)
+			assert.Equal(t, 0.0, staging.Summary.ChangeFailureRate)
+		}
+
+		assert.Equal(t, 4, metrics.Pipeline.Summary.Deployments)
+		assert.Equal(t, 1, metrics.Pipeline.Summary.FailedDeployments)
+		assert.Equal(t, 0.2, metrics.Pipeline.Summary.ChangeFailureRate)
+		// the first commit is released to both staging and production, but only counted once for the pipeline
+		assert.Equal(t, 4, metrics.Pipeline.Summary.Changes)
+	})
+
+	t.Run("CountsCommitAtFirstReleaseAcrossTargets", func(t *testing.T) {
+
+		builds, releases := getDoraMetricsBuildsAndReleases()
+
+		// act
+		metrics := GetDoraMetrics(builds, releases[:2], DoraMetricsOptions{
+			From:   getDoraMetricsTime(0),
+			To:     getDoraMetricsTime(24 * time.Hour),
+			Period: 24 * time.Hour,
+		})
+
+		assert.Equal(t, 1, metrics.Pipeline.Summary.Changes)
+		// staging finished at 100 minutes, before production at 2 hours
+		assert.Equal(t, 40*time.Minute, metrics.Pipeline.Summary.MedianLeadTime)
+	})
+
+	t.Run("LeavesOutBuildsAndReleasesOfOtherRepos", func(t *testing.T) {
+
+		builds, releases := getDoraMetricsBuildsAndReleases()
+		otherBuilds, otherReleases := getDoraMetricsBuildsAndReleases()
+		for _, b := range otherBuilds {
+			b.RepoName = "ziplinee-ci-web"
+		}
+		for _, r := range otherReleases {
+			r.RepoName = "ziplinee-ci-web"
+		}
+
+		// act
+		metrics := GetDoraMetrics(append(builds, otherBuilds...), append(releases, otherReleases...), DoraMetricsOptions{})
+
+		assert.Equal(t, "ziplinee-ci-api", metrics.RepoName)
+		assert.Equal(t, 4, metrics.Pipeline.Summary.Deployments)
+		assert.Equal(t, 4, metrics.Pipeline.Summary.Changes)
+	})
+
+	t.Run("DefaultsTimeRangeToFinishedReleases", func(t *testing.T) {
+
+		builds, releases := getDoraMetricsBuildsAndReleases()
+
+		// act
+		metrics := GetDoraMetrics(builds, releases, DoraMetricsOptions{})
+
+		assert.Equal(t, getDoraMetricsTime(100*time.Minute), metrics.From)
+		assert.Equal(t, getDoraMetricsTime(32*time.Hour), metrics.To)
+		assert.Equal(t, DefaultDoraMetricsPeriod, metrics.Period)
+		assert.Equal(t, 1, len(metrics.Pipeline.Buckets))
+		assert.Equal(t, 4, metrics.Pipeline.Buckets[0].Deployments)
+	})
+
+	t.Run("ReturnsEmptyMetricsWithoutReleasesAndOnlyToSet", func(t *testing.T) {
+
+		// act
+		metrics := GetDoraMetrics(nil, nil, DoraMetricsOptions{To: getDoraMetricsTime(0), Period: time.Hour})
+
+		assert.Equal(t, 0, len(metrics.ReleaseTargets))
+		assert.Equal(t, 0, len(metrics.Pipeline.Buckets))
+		assert.True(t, metrics.From.IsZero())
+	})
+
+	t.Run("CapsNumberOfBuckets", func(t *testing.T) {
+
+		builds, releases := getDoraMetricsBuildsAndReleases()
+
+		// act
+		metrics := GetDoraMetrics(builds, releases, DoraMetricsOptions{From: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), To: getDoraMetricsTime(48 * time.Hour), Period: time.Minute})
+
+		assert.Equal(t, MaxDoraMetricsBuckets, len(metrics.Pipeline.Buckets))
+		assert.Equal(t, getDoraMetricsTime(48*time.Hour-MaxDoraMetricsBuckets*time.Minute), metrics.From)
+	})
+
+	t.Run("ReturnsEmptyMetricsWithoutReleases", func(t *testing.T) {
+
+		// act
+		metrics := GetDoraMetrics(nil, nil, DoraMetricsOptions{})
+
+		assert.Equal(t, 0, len(metrics.ReleaseTargets))
+		assert.Equal(t, 0, metrics.Pipeline.Summary.Deployments)
+	})
+}
+
+func TestGetDoraMetricsPerRepo(t *testing.T) {
+	t.Run("ComputesMetricsForEachRepo", func(t *testing.T) {
+
+		builds, releases := getDoraMetricsBuildsAndReleases()
+		otherBuilds, otherReleases := getDoraMetricsBuildsAndReleases()
+		for _, b := range otherBuilds {
+			b.RepoName = "ziplinee-ci-web"
+		}
+		for _, r := range otherReleases[:2] {
+			r.RepoName = "ziplinee-ci-web"
+		}
+
+		// act
+		metrics := GetDoraMetricsPerRepo(append(otherBuilds, builds...), append(otherReleases[:2], releases...), DoraMetricsOptions{})
+
+		if assert.Equal(t, 2, len(metrics)) {
+			assert.Equal(t, "ziplinee-ci-api", metrics[0].RepoName)
+			assert.Equal(t, 4, metrics[0].Pipeline.Summary.Deployments)
+			assert.Equal(t, 4, metrics[0].Pipeline.Summary.Changes)
+			assert.Equal(t, "ziplinee-ci-web", metrics[1].RepoName)
+			assert.Equal(t, 2, metrics[1].Pipeline.Summary.Deployments)
+			assert.Equal(t, 1, metrics[1].Pipeline.Summary.Changes)
+		}
+	})
+}
+
+func getDoraMetricsTime(offset time.Duration) time.Time {
+	return time.Date(2018, 4, 17, 0, 0, 0, 0, time.UTC).Add(offset)
+}
+
+func getDoraMetricsBuildsAndReleases() ([]*Build, []*Release) {
+	getBuild := func(version string, offset time.Duration, messages ...string) *Build {
+		commits := []GitCommit{}
+		for _, m := range messages {
+			commits = append(commits, GitCommit{Message: m, Author: GitAuthor{Email: "jane@example.com"}})
+		}
+		branch := "master"
+		if strings.Contains(version, "-") {
+			branch = "feature"
+		}
+		return &Build{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", RepoBranch: branch, BuildVersion: version, BuildStatus: StatusSucceeded, Commits: commits, InsertedAt: getDoraMetricsTime(offset)}
+	}
+	getRelease := func(name, version string, status Status, offset, duration time.Duration) *Release {
+		startedAt := getDoraMetricsTime(offset)
+		return &Release{
+			Name:           name,
+			RepoSource:     "github.com",
+			RepoOwner:      "ziplineeci",
+			RepoName:       "ziplinee-ci-api",
+			ReleaseVersion: version,
+			ReleaseStatus:  status,
+			StartedAt:      &startedAt,
+			Duration:       &duration,
+		}
+	}
+
+	builds := []*Build{
+		getBuild("1.0.0", time.Hour, "feat: first"),
+		getBuild("1.0.1", 25*time.Hour, "feat: second"),
+		getBuild("1.0.2", 26*time.Hour, "fix: third", "feat: second"),
+		getBuild("1.0.3", 27*time.Hour, "fix: fourth"),
+		getBuild("1.0.3-feature", 26*time.Hour+30*time.Minute, "feat: unreleased feature"),
+	}
+
+	releases := []*Release{
+		getRelease("production", "1.0.0", StatusSucceeded, 2*time.Hour-10*time.Minute, 10*time.Minute),
+		getRelease("staging", "1.0.0", StatusSucceeded, 90*time.Minute, 10*time.Minute),
+		getRelease("production", "1.0.2", StatusFailed, 28*time.Hour-10*time.Minute, 10*time.Minute),
+		getRelease("production", "1.0.2", StatusSucceeded, 30*time.Hour-10*time.Minute, 10*time.Minute),
+		getRelease("production", "1.0.3", StatusSucceeded, 32*time.Hour-10*time.Minute, 10*time.Minute),
+		getRelease("production", "1.0.3", StatusCanceled, 33*time.Hour, 10*time.Minute),
+	}
+
+	return builds, releases
+}
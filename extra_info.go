@@ -0,0 +1,230 @@
+package contracts
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ExtraInfoTrend compares the statistics of the latest window of jobs with the window before it; a positive delta means an increase
+type ExtraInfoTrend struct {
+	MedianPendingDurationDelta time.Duration `json:"medianPendingDurationDelta"`
+	MedianDurationDelta        time.Duration `json:"medianDurationDelta"`
+	SuccessRateDelta           float64       `json:"successRateDelta"`
+	PreviousCount              int           `json:"previousCount"`
+}
+
+// GetPipelineExtraInfo computes duration percentiles and success rates over the last windowSize finished builds and
+// the trend compared to the windowSize builds before those; a windowSize of 0 uses all builds and leaves out the trend
+func GetPipelineExtraInfo(builds []*Build, windowSize int) *PipelineExtraInfo {
+
+	samples := make([]jobSample, 0, len(builds))
+	for _, b := range builds {
+		if b == nil {
+			continue
+		}
+		duration := b.Duration
+		samples = append(samples, jobSample{insertedAt: b.InsertedAt, status: b.BuildStatus, duration: &duration, pendingDuration: b.PendingDuration})
+	}
+
+	current, trend := getJobStatistics(samples, windowSize)
+	if current == nil {
+		return nil
+	}
+
+	return &PipelineExtraInfo{
+		MedianPendingDuration: current.medianPendingDuration,
+		MedianDuration:        current.medianDuration,
+		P90PendingDuration:    current.p90PendingDuration,
+		P95PendingDuration:    current.p95PendingDuration,
+		P90Duration:           current.p90Duration,
+		P95Duration:           current.p95Duration,
+		SuccessRate:           current.successRate,
+		FailureRate:           current.failureRate,
+		Count:                 current.count,
+		Trend:                 trend,
+	}
+}
+
+// GetReleaseExtraInfo computes duration percentiles and success rates over the last windowSize finished releases and
+// the trend compared to the windowSize releases before those; a windowSize of 0 uses all releases and leaves out the trend
+func GetReleaseExtraInfo(releases []*Release, windowSize int) *ReleaseExtraInfo {
+
+	samples := make([]jobSample, 0, len(releases))
+	for _, r := range releases {
+		if r == nil {
+			continue
+		}
+		samples = append(samples, jobSample{insertedAt: getTimeOrZero(r.InsertedAt), status: r.ReleaseStatus, duration: r.Duration, pendingDuration: r.PendingDuration})
+	}
+
+	current, trend := getJobStatistics(samples, windowSize)
+	if current == nil {
+		return nil
+	}
+
+	return &ReleaseExtraInfo{
+		MedianPendingDuration: current.medianPendingDuration,
+		MedianDuration:        current.medianDuration,
+		P90PendingDuration:    current.p90PendingDuration,
+		P95PendingDuration:    current.p95PendingDuration,
+		P90Duration:           current.p90Duration,
+		P95Duration:           current.p95Duration,
+		SuccessRate:           current.successRate,
+		FailureRate:           current.failureRate,
+		Count:                 current.count,
+		Trend:                 trend,
+	}
+}
+
+// GetBotExtraInfo computes duration percentiles and success rates over the last windowSize finished bot executions and
+// the trend compared to the windowSize executions before those; a windowSize of 0 uses all executions and leaves out the trend
+func GetBotExtraInfo(bots []*Bot, windowSize int) *BotExtraInfo {
+
+	samples := make([]jobSample, 0, len(bots))
+	for _, b := range bots {
+		if b == nil {
+			continue
+		}
+		samples = append(samples, jobSample{insertedAt: getTimeOrZero(b.InsertedAt), status: b.BotStatus, duration: b.Duration, pendingDuration: b.PendingDuration})
+	}
+
+	current, trend := getJobStatistics(samples, windowSize)
+	if current == nil {
+		return nil
+	}
+
+	return &BotExtraInfo{
+		MedianPendingDuration: current.medianPendingDuration,
+		MedianDuration:        current.medianDuration,
+		P90PendingDuration:    current.p90PendingDuration,
+		P95PendingDuration:    current.p95PendingDuration,
+		P90Duration:           current.p90Duration,
+		P95Duration:           current.p95Duration,
+		SuccessRate:           current.successRate,
+		FailureRate:           current.failureRate,
+		Count:                 current.count,
+		Trend:                 trend,
+	}
+}
+
+type jobSample struct {
+	insertedAt      time.Time
+	status          Status
+	duration        *time.Duration
+	pendingDuration *time.Duration
+}
+
+type jobStatistics struct {
+	medianPendingDuration time.Duration
+	p90PendingDuration    time.Duration
+	p95PendingDuration    time.Duration
+	medianDuration        time.Duration
+	p90Duration           time.Duration
+	p95Duration           time.Duration
+	successRate           float64
+	failureRate           float64
+	count                 int
+}
+
+// getJobStatistics returns the statistics for the latest window and the trend versus the window before it; jobs that
+// haven't finished yet are left out, canceled jobs count towards durations but not towards success and failure rates
+func getJobStatistics(samples []jobSample, windowSize int) (*jobStatistics, *ExtraInfoTrend) {
+
+	finished := make([]jobSample, 0, len(samples))
+	for _, s := range samples {
+		if s.status == StatusSucceeded || s.status == StatusFailed || s.status == StatusCanceled {
+			finished = append(finished, s)
+		}
+	}
+	if len(finished) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finished[i].insertedAt.After(finished[j].insertedAt)
+	})
+
+	if windowSize <= 0 || windowSize >= len(finished) {
+		return getWindowStatistics(finished), nil
+	}
+
+	current := getWindowStatistics(finished[:windowSize])
+
+	previousWindow := finished[windowSize:]
+	if len(previousWindow) > windowSize {
+		previousWindow = previousWindow[:windowSize]
+	}
+	previous := getWindowStatistics(previousWindow)
+
+	return current, &ExtraInfoTrend{
+		MedianPendingDurationDelta: current.medianPendingDuration - previous.medianPendingDuration,
+		MedianDurationDelta:        current.medianDuration - previous.medianDuration,
+		SuccessRateDelta:           current.successRate - previous.successRate,
+		PreviousCount:              previous.count,
+	}
+}
+
+func getWindowStatistics(samples []jobSample) *jobStatistics {
+
+	durations := []time.Duration{}
+	pendingDurations := []time.Duration{}
+	succeeded, failed := 0, 0
+
+	for _, s := range samples {
+		if s.duration != nil {
+			durations = append(durations, *s.duration)
+		}
+		if s.pendingDuration != nil {
+			pendingDurations = append(pendingDurations, *s.pendingDuration)
+		}
+		switch s.status {
+		case StatusSucceeded:
+			succeeded++
+		case StatusFailed:
+			failed++
+		}
+	}
+
+	statistics := &jobStatistics{
+		medianPendingDuration: getPercentileDuration(pendingDurations, 0.5),
+		p90PendingDuration:    getPercentileDuration(pendingDurations, 0.9),
+		p95PendingDuration:    getPercentileDuration(pendingDurations, 0.95),
+		medianDuration:        getPercentileDuration(durations, 0.5),
+		p90Duration:           getPercentileDuration(durations, 0.9),
+		p95Duration:           getPercentileDuration(durations, 0.95),
+		count:                 len(samples),
+	}
+	if succeeded+failed > 0 {
+		statistics.successRate = float64(succeeded) / float64(succeeded+failed)
+		statistics.failureRate = float64(failed) / float64(succeeded+failed)
+	}
+
+	return statistics
+}
+
+// getPercentileDuration interpolates linearly between the closest ranks, like percentile_cont in sql
+func getPercentileDuration(durations []time.Duration, percentile float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	rank := percentile * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + time.Duration(math.Round(float64(sorted[upper]-sorted[lower])*(rank-float64(lower))))
+}
+
+func getTimeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
@@ -0,0 +1,145 @@
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPipelineExtraInfo(t *testing.T) {
+	t.Run("ReturnsPercentilesAndRatesOverAllBuilds", func(t *testing.T) {
+
+		builds := getExtraInfoBuilds()
+
+		// act
+		extraInfo := GetPipelineExtraInfo(builds, 0)
+
+		if assert.NotNil(t, extraInfo) {
+			// the running build is left out
+			assert.Equal(t, 10, extraInfo.Count)
+			assert.Equal(t, 55*time.Second, extraInfo.MedianDuration)
+			assert.Equal(t, 91*time.Second, extraInfo.P90Duration)
+			assert.Equal(t, 95500*time.Millisecond, extraInfo.P95Duration)
+			assert.Equal(t, 5500*time.Millisecond, extraInfo.MedianPendingDuration)
+			assert.InDelta(t, 7.0/9.0, extraInfo.SuccessRate, 0.0001)
+			assert.InDelta(t, 2.0/9.0, extraInfo.FailureRate, 0.0001)
+			assert.Nil(t, extraInfo.Trend)
+		}
+	})
+
+	t.Run("ReturnsTrendVersusPreviousWindow", func(t *testing.T) {
+
+		builds := getExtraInfoBuilds()
+
+		// act
+		extraInfo := GetPipelineExtraInfo(builds, 5)
+
+		if assert.NotNil(t, extraInfo) && assert.NotNil(t, extraInfo.Trend) {
+			// latest window holds the builds taking 60s to 100s, the previous one 10s to 50s
+			assert.Equal(t, 5, extraInfo.Count)
+			assert.Equal(t, 80*time.Second, extraInfo.MedianDuration)
+			assert.Equal(t, 50*time.Second, extraInfo.Trend.MedianDurationDelta)
+			assert.Equal(t, 5*time.Second, extraInfo.Trend.MedianPendingDurationDelta)
+			assert.Equal(t, 5, extraInfo.Trend.PreviousCount)
+			assert.InDelta(t, 0.5-1.0, extraInfo.Trend.SuccessRateDelta, 0.0001)
+		}
+	})
+
+	t.Run("ReturnsNilWithoutFinishedBuilds", func(t *testing.T) {
+
+		// act
+		extraInfo := GetPipelineExtraInfo([]*Build{&Build{BuildStatus: StatusRunning}}, 0)
+
+		assert.Nil(t, extraInfo)
+	})
+
+	t.Run("KeepsZeroSuccessRateInJSONIfAllBuildsFailed", func(t *testing.T) {
+
+		extraInfo := GetPipelineExtraInfo([]*Build{{BuildStatus: StatusFailed, Duration: time.Minute}}, 0)
+
+		// act
+		bytes, err := json.Marshal(extraInfo)
+
+		assert.Nil(t, err)
+		assert.Contains(t, string(bytes), `"successRate":0,"failureRate":1`)
+	})
+
+	t.Run("KeepsJSONOfExistingFields", func(t *testing.T) {
+
+		extraInfo := PipelineExtraInfo{MedianPendingDuration: time.Second, MedianDuration: time.Minute}
+
+		// act
+		bytes, err := json.Marshal(extraInfo)
+
+		assert.Nil(t, err)
+		assert.Equal(t, `{"medianPendingDuration":1000000000,"medianDuration":60000000000,"successRate":0,"failureRate":0}`, string(bytes))
+	})
+}
+
+func TestGetReleaseExtraInfo(t *testing.T) {
+	t.Run("IgnoresMissingDurations", func(t *testing.T) {
+
+		insertedAt := time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC)
+		duration := 20 * time.Second
+		releases := []*Release{
+			&Release{ReleaseStatus: StatusSucceeded, InsertedAt: &insertedAt, Duration: &duration},
+			&Release{ReleaseStatus: StatusCanceled},
+		}
+
+		// act
+		extraInfo := GetReleaseExtraInfo(releases, 0)
+
+		if assert.NotNil(t, extraInfo) {
+			assert.Equal(t, 2, extraInfo.Count)
+			assert.Equal(t, 20*time.Second, extraInfo.MedianDuration)
+			assert.Equal(t, time.Duration(0), extraInfo.MedianPendingDuration)
+			assert.Equal(t, 1.0, extraInfo.SuccessRate)
+			assert.Equal(t, 0.0, extraInfo.FailureRate)
+		}
+	})
+}
+
+func TestGetBotExtraInfo(t *testing.T) {
+	t.Run("ReturnsMedianDuration", func(t *testing.T) {
+
+		first, second := 10*time.Second, 30*time.Second
+		bots := []*Bot{
+			&Bot{BotStatus: StatusSucceeded, Duration: &first},
+			&Bot{BotStatus: StatusFailed, Duration: &second},
+		}
+
+		// act
+		extraInfo := GetBotExtraInfo(bots, 0)
+
+		if assert.NotNil(t, extraInfo) {
+			assert.Equal(t, 20*time.Second, extraInfo.MedianDuration)
+			assert.Equal(t, 0.5, extraInfo.FailureRate)
+		}
+	})
+}
+
+// getExtraInfoBuilds returns 10 finished builds taking 10s to 100s and pending 1s to 10s, inserted a minute apart, plus a running build;
+// the 5 oldest all succeeded, of the 5 latest the builds taking 70s and 90s failed and the one taking 100s got canceled
+func getExtraInfoBuilds() []*Build {
+	builds := []*Build{}
+	for i := 1; i <= 10; i++ {
+		status := StatusSucceeded
+		switch i {
+		case 7, 9:
+			status = StatusFailed
+		case 10:
+			status = StatusCanceled
+		}
+		pendingDuration := time.Duration(i) * time.Second
+		builds = append(builds, &Build{
+			BuildStatus:     status,
+			InsertedAt:      time.Date(2018, 4, 17, 8, i, 0, 0, time.UTC),
+			Duration:        time.Duration(i*10) * time.Second,
+			PendingDuration: &pendingDuration,
+		})
+	}
+
+	return append(builds, &Build{BuildStatus: StatusRunning, InsertedAt: time.Date(2018, 4, 17, 9, 0, 0, 0, time.UTC)})
+}
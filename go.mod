@@ -5,6 +5,8 @@ go 1.22.2
 require (
 	github.com/stretchr/testify v1.9.0
 	github.com/ziplineeci/ziplinee-ci-manifest v0.0.2
+	google.golang.org/grpc v1.65.0
+	google.golang.org/protobuf v1.34.2
 	gopkg.in/yaml.v2 v2.4.0
 )
 
@@ -33,7 +35,9 @@ require (
 	github.com/uber/jaeger-lib v2.4.1+incompatible // indirect
 	github.com/ziplineeci/ziplinee-foundation v0.0.2 // indirect
 	go.uber.org/atomic v1.11.0 // indirect
+	golang.org/x/net v0.26.0 // indirect
 	golang.org/x/sys v0.22.0 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
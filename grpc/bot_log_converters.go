@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+)
+
+// BotLogToProto converts a bot log contract into its protobuf message
+func BotLogToProto(botLog *contracts.BotLog) *BotLog {
+	if botLog == nil {
+		return nil
+	}
+
+	return &BotLog{
+		Id:         botLog.ID,
+		RepoSource: botLog.RepoSource,
+		RepoOwner:  botLog.RepoOwner,
+		RepoName:   botLog.RepoName,
+		BotId:      botLog.BotID,
+		Steps:      BuildLogStepsToProto(botLog.Steps),
+		InsertedAt: timeToProto(botLog.InsertedAt),
+	}
+}
+
+// BotLogFromProto converts a protobuf message into a bot log contract
+func BotLogFromProto(botLog *BotLog) *contracts.BotLog {
+	if botLog == nil {
+		return nil
+	}
+
+	return &contracts.BotLog{
+		ID:         botLog.GetId(),
+		RepoSource: botLog.GetRepoSource(),
+		RepoOwner:  botLog.GetRepoOwner(),
+		RepoName:   botLog.GetRepoName(),
+		BotID:      botLog.GetBotId(),
+		Steps:      nonNilSteps(BuildLogStepsFromProto(botLog.GetSteps())),
+		InsertedAt: timeFromProto(botLog.GetInsertedAt()),
+	}
+}
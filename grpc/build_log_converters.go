@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"time"
+
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BuildLogToProto converts a build log contract into its protobuf message
+func BuildLogToProto(buildLog *contracts.BuildLog) *BuildLog {
+	if buildLog == nil {
+		return nil
+	}
+
+	return &BuildLog{
+		Id:           buildLog.ID,
+		RepoSource:   buildLog.RepoSource,
+		RepoOwner:    buildLog.RepoOwner,
+		RepoName:     buildLog.RepoName,
+		RepoBranch:   buildLog.RepoBranch,
+		RepoRevision: buildLog.RepoRevision,
+		BuildId:      buildLog.BuildID,
+		Steps:        BuildLogStepsToProto(buildLog.Steps),
+		InsertedAt:   timeToProto(buildLog.InsertedAt),
+	}
+}
+
+// BuildLogFromProto converts a protobuf message into a build log contract; protobuf can't tell nil from empty lists
+// and doesn't carry time zones, so steps and log lines always come back as non-nil lists and times in UTC
+func BuildLogFromProto(buildLog *BuildLog) *contracts.BuildLog {
+	if buildLog == nil {
+		return nil
+	}
+
+	return &contracts.BuildLog{
+		ID:           buildLog.GetId(),
+		RepoSource:   buildLog.GetRepoSource(),
+		RepoOwner:    buildLog.GetRepoOwner(),
+		RepoName:     buildLog.GetRepoName(),
+		RepoBranch:   buildLog.GetRepoBranch(),
+		RepoRevision: buildLog.GetRepoRevision(),
+		BuildID:      buildLog.GetBuildId(),
+		Steps:        nonNilSteps(BuildLogStepsFromProto(buildLog.GetSteps())),
+		InsertedAt:   timeFromProto(buildLog.GetInsertedAt()),
+	}
+}
+
+// BuildLogStepsToProto converts build log steps into protobuf messages; nil steps are left out since protobuf can't represent them
+func BuildLogStepsToProto(steps []*contracts.BuildLogStep) []*BuildLogStep {
+	if steps == nil {
+		return nil
+	}
+
+	protoSteps := make([]*BuildLogStep, 0, len(steps))
+	for _, s := range steps {
+		if s == nil {
+			continue
+		}
+		protoSteps = append(protoSteps, BuildLogStepToProto(s))
+	}
+
+	return protoSteps
+}
+
+// BuildLogStepsFromProto converts protobuf messages into build log steps; empty lists are returned as nil
+func BuildLogStepsFromProto(steps []*BuildLogStep) []*contracts.BuildLogStep {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	contractSteps := make([]*contracts.BuildLogStep, 0, len(steps))
+	for _, s := range steps {
+		contractSteps = append(contractSteps, BuildLogStepFromProto(s))
+	}
+
+	return contractSteps
+}
+
+// BuildLogStepToProto converts a build log step, including its nested steps and services, into its protobuf message
+func BuildLogStepToProto(step *contracts.BuildLogStep) *BuildLogStep {
+	if step == nil {
+		return nil
+	}
+
+	protoStep := &BuildLogStep{
+		Step:         step.Step,
+		Depth:        int32(step.Depth),
+		Image:        BuildLogStepDockerImageToProto(step.Image),
+		RunIndex:     int32(step.RunIndex),
+		Duration:     durationpb.New(step.Duration),
+		ExitCode:     step.ExitCode,
+		Status:       string(step.Status),
+		AutoInjected: step.AutoInjected,
+		NestedSteps:  BuildLogStepsToProto(step.NestedSteps),
+		Services:     BuildLogStepsToProto(step.Services),
+	}
+
+	if step.LogLines != nil {
+		protoStep.LogLines = make([]*BuildLogLine, 0, len(step.LogLines))
+		for _, l := range step.LogLines {
+			protoStep.LogLines = append(protoStep.LogLines, BuildLogLineToProto(l))
+		}
+	}
+
+	return protoStep
+}
+
+// BuildLogStepFromProto converts a protobuf message into a build log step, including its nested steps and services;
+// log lines are always returned as a non-nil list, matching the json contracts that always serialize them
+func BuildLogStepFromProto(step *BuildLogStep) *contracts.BuildLogStep {
+	if step == nil {
+		return nil
+	}
+
+	contractStep := &contracts.BuildLogStep{
+		Step:         step.GetStep(),
+		Depth:        int(step.GetDepth()),
+		Image:        BuildLogStepDockerImageFromProto(step.GetImage()),
+		RunIndex:     int(step.GetRunIndex()),
+		Duration:     step.GetDuration().AsDuration(),
+		LogLines:     make([]contracts.BuildLogLine, 0, len(step.GetLogLines())),
+		ExitCode:     step.GetExitCode(),
+		Status:       contracts.LogStatus(step.GetStatus()),
+		AutoInjected: step.GetAutoInjected(),
+		NestedSteps:  BuildLogStepsFromProto(step.GetNestedSteps()),
+		Services:     BuildLogStepsFromProto(step.GetServices()),
+	}
+
+	for _, l := range step.GetLogLines() {
+		contractStep.LogLines = append(contractStep.LogLines, BuildLogLineFromProto(l))
+	}
+
+	return contractStep
+}
+
+// BuildLogStepDockerImageToProto converts docker image info into its protobuf message
+func BuildLogStepDockerImageToProto(image *contracts.BuildLogStepDockerImage) *BuildLogStepDockerImage {
+	if image == nil {
+		return nil
+	}
+
+	return &BuildLogStepDockerImage{
+		Name:                   image.Name,
+		Tag:                    image.Tag,
+		IsPulled:               image.IsPulled,
+		ImageSize:              image.ImageSize,
+		PullDuration:           durationpb.New(image.PullDuration),
+		Error:                  image.Error,
+		IsTrusted:              image.IsTrusted,
+		HasInjectedCredentials: image.HasInjectedCredentials,
+	}
+}
+
+// BuildLogStepDockerImageFromProto converts a protobuf message into docker image info
+func BuildLogStepDockerImageFromProto(image *BuildLogStepDockerImage) *contracts.BuildLogStepDockerImage {
+	if image == nil {
+		return nil
+	}
+
+	return &contracts.BuildLogStepDockerImage{
+		Name:                   image.GetName(),
+		Tag:                    image.GetTag(),
+		IsPulled:               image.GetIsPulled(),
+		ImageSize:              image.GetImageSize(),
+		PullDuration:           image.GetPullDuration().AsDuration(),
+		Error:                  image.GetError(),
+		IsTrusted:              image.GetIsTrusted(),
+		HasInjectedCredentials: image.GetHasInjectedCredentials(),
+	}
+}
+
+// BuildLogLineToProto converts a log line into its protobuf message
+func BuildLogLineToProto(line contracts.BuildLogLine) *BuildLogLine {
+	return &BuildLogLine{
+		LineNumber: int32(line.LineNumber),
+		Timestamp:  timeToProto(line.Timestamp),
+		StreamType: line.StreamType,
+		Text:       line.Text,
+	}
+}
+
+// BuildLogLineFromProto converts a protobuf message into a log line
+func BuildLogLineFromProto(line *BuildLogLine) contracts.BuildLogLine {
+	return contracts.BuildLogLine{
+		LineNumber: int(line.GetLineNumber()),
+		Timestamp:  timeFromProto(line.GetTimestamp()),
+		StreamType: line.GetStreamType(),
+		Text:       line.GetText(),
+	}
+}
+
+// timeToProto leaves zero times out of the message; protobuf timestamps have no location, so other times come back
+// from timeFromProto as the same instant in UTC
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return t.AsTime()
+}
+
+// nonNilSteps returns an empty list instead of nil, since steps are always serialized in the json contracts
+func nonNilSteps(steps []*contracts.BuildLogStep) []*contracts.BuildLogStep {
+	if steps == nil {
+		return []*contracts.BuildLogStep{}
+	}
+
+	return steps
+}
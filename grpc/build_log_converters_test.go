@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBuildLogConverters(t *testing.T) {
+	t.Run("RoundTripsBuildLogThroughProtobuf", func(t *testing.T) {
+
+		buildLog := getBuildLog()
+
+		// act
+		bytes, err := proto.Marshal(BuildLogToProto(buildLog))
+		assert.Nil(t, err)
+		var protoBuildLog BuildLog
+		err = proto.Unmarshal(bytes, &protoBuildLog)
+		assert.Nil(t, err)
+		roundTripped := BuildLogFromProto(&protoBuildLog)
+
+		assert.Equal(t, buildLog, roundTripped)
+	})
+
+	t.Run("RoundTripsToIdenticalJSON", func(t *testing.T) {
+
+		buildLog := getBuildLog()
+		expected, err := json.Marshal(buildLog)
+		assert.Nil(t, err)
+
+		// act
+		actual, err := json.Marshal(BuildLogFromProto(BuildLogToProto(buildLog)))
+
+		assert.Nil(t, err)
+		assert.Equal(t, string(expected), string(actual))
+	})
+
+	t.Run("MapsNewFields", func(t *testing.T) {
+
+		buildLog := getBuildLog()
+
+		// act
+		protoBuildLog := BuildLogToProto(buildLog)
+
+		assert.Equal(t, "15", protoBuildLog.GetBuildId())
+		assert.Equal(t, int32(1), protoBuildLog.GetSteps()[0].GetNestedSteps()[0].GetDepth())
+		assert.Equal(t, int32(1), protoBuildLog.GetSteps()[1].GetRunIndex())
+		assert.Equal(t, "postgres", protoBuildLog.GetSteps()[0].GetServices()[0].GetStep())
+		assert.Equal(t, int32(2), protoBuildLog.GetSteps()[0].GetLogLines()[1].GetLineNumber())
+		assert.True(t, protoBuildLog.GetSteps()[0].GetImage().GetHasInjectedCredentials())
+	})
+
+	t.Run("SkipsNilSteps", func(t *testing.T) {
+
+		buildLog := &contracts.BuildLog{
+			Steps: []*contracts.BuildLogStep{nil, &contracts.BuildLogStep{Step: "build"}},
+		}
+
+		// act
+		protoBuildLog := BuildLogToProto(buildLog)
+
+		assert.Equal(t, 1, len(protoBuildLog.GetSteps()))
+	})
+
+	t.Run("ReturnsNilForNil", func(t *testing.T) {
+
+		// act
+		protoBuildLog := BuildLogToProto(nil)
+		buildLog := BuildLogFromProto(nil)
+
+		assert.Nil(t, protoBuildLog)
+		assert.Nil(t, buildLog)
+	})
+
+	t.Run("ConvertsZeroTimesToNilTimestamps", func(t *testing.T) {
+
+		// act
+		protoLine := BuildLogLineToProto(contracts.BuildLogLine{Text: "no time"})
+		line := BuildLogLineFromProto(protoLine)
+
+		assert.Nil(t, protoLine.GetTimestamp())
+		assert.True(t, line.Timestamp.IsZero())
+	})
+
+	t.Run("RoundTripsNilLogLinesAsEmptyListAndTimesInUTC", func(t *testing.T) {
+
+		insertedAt := time.Date(2018, 4, 17, 10, 3, 0, 0, time.FixedZone("CEST", 2*60*60))
+		buildLog := &contracts.BuildLog{
+			BuildID:    "15",
+			Steps:      []*contracts.BuildLogStep{&contracts.BuildLogStep{Step: "build"}},
+			InsertedAt: insertedAt,
+		}
+
+		// act
+		bytes, err := proto.Marshal(BuildLogToProto(buildLog))
+		assert.Nil(t, err)
+		var protoBuildLog BuildLog
+		err = proto.Unmarshal(bytes, &protoBuildLog)
+		assert.Nil(t, err)
+		roundTripped := BuildLogFromProto(&protoBuildLog)
+
+		assert.Nil(t, buildLog.Steps[0].LogLines)
+		assert.Equal(t, []contracts.BuildLogLine{}, roundTripped.Steps[0].LogLines)
+		assert.Equal(t, time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC), roundTripped.InsertedAt)
+		assert.True(t, insertedAt.Equal(roundTripped.InsertedAt))
+	})
+}
+
+func getBuildLog() *contracts.BuildLog {
+	return &contracts.BuildLog{
+		ID:           "5",
+		RepoSource:   "github.com",
+		RepoOwner:    "ziplineeci",
+		RepoName:     "ziplinee-ci-api",
+		RepoBranch:   "master",
+		RepoRevision: "as23456",
+		BuildID:      "15",
+		Steps: []*contracts.BuildLogStep{
+			&contracts.BuildLogStep{
+				Step: "build",
+				Image: &contracts.BuildLogStepDockerImage{
+					Name:                   "golang",
+					Tag:                    "1.22-alpine",
+					IsPulled:               true,
+					ImageSize:              135000,
+					PullDuration:           2 * time.Second,
+					IsTrusted:              true,
+					HasInjectedCredentials: true,
+				},
+				Duration: 91 * time.Second,
+				LogLines: []contracts.BuildLogLine{
+					contracts.BuildLogLine{LineNumber: 1, Timestamp: time.Date(2018, 4, 17, 8, 3, 0, 123000000, time.UTC), StreamType: "stdout", Text: "go build ./..."},
+					contracts.BuildLogLine{LineNumber: 2, Timestamp: time.Date(2018, 4, 17, 8, 3, 1, 0, time.UTC), StreamType: "stderr", Text: "warning"},
+				},
+				Status:       contracts.LogStatusSucceeded,
+				AutoInjected: true,
+				NestedSteps: []*contracts.BuildLogStep{
+					&contracts.BuildLogStep{
+						Step:     "lint",
+						Depth:    1,
+						Duration: 5 * time.Second,
+						LogLines: []contracts.BuildLogLine{},
+						Status:   contracts.LogStatusSucceeded,
+					},
+				},
+				Services: []*contracts.BuildLogStep{
+					&contracts.BuildLogStep{
+						Step: "postgres",
+						Image: &contracts.BuildLogStepDockerImage{
+							Name:  "postgres",
+							Tag:   "16",
+							Error: "pull access denied",
+						},
+						Depth:    1,
+						LogLines: []contracts.BuildLogLine{},
+						ExitCode: 1,
+						Status:   contracts.LogStatusFailed,
+					},
+				},
+			},
+			&contracts.BuildLogStep{
+				Step:     "test",
+				RunIndex: 1,
+				Duration: 7 * time.Second,
+				LogLines: []contracts.BuildLogLine{},
+				Status:   contracts.LogStatusSucceeded,
+			},
+		},
+		InsertedAt: time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,5 @@
+// Package grpc contains the protobuf messages and services generated from estafette_ci_api.proto and conversions
+// between those messages and the json contracts in the parent package
+package grpc
+
+//go:generate protoc --go_out=.. --go_opt=module=github.com/ziplineeci/ziplinee-ci-contracts --go-grpc_out=.. --go-grpc_opt=module=github.com/ziplineeci/ziplinee-ci-contracts -I.. ../estafette_ci_api.proto
@@ -0,0 +1,1417 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: estafette_ci_api.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BuildLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RepoSource   string                 `protobuf:"bytes,2,opt,name=repo_source,json=repoSource,proto3" json:"repo_source,omitempty"`
+	RepoOwner    string                 `protobuf:"bytes,3,opt,name=repo_owner,json=repoOwner,proto3" json:"repo_owner,omitempty"`
+	RepoName     string                 `protobuf:"bytes,4,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	RepoBranch   string                 `protobuf:"bytes,5,opt,name=repo_branch,json=repoBranch,proto3" json:"repo_branch,omitempty"`
+	RepoRevision string                 `protobuf:"bytes,6,opt,name=repo_revision,json=repoRevision,proto3" json:"repo_revision,omitempty"`
+	Steps        []*BuildLogStep        `protobuf:"bytes,7,rep,name=steps,proto3" json:"steps,omitempty"`
+	InsertedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=inserted_at,json=insertedAt,proto3" json:"inserted_at,omitempty"`
+	BuildId      string                 `protobuf:"bytes,9,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+}
+
+func (x *BuildLog) Reset() {
+	*x = BuildLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildLog) ProtoMessage() {}
+
+func (x *BuildLog) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildLog.ProtoReflect.Descriptor instead.
+func (*BuildLog) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BuildLog) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BuildLog) GetRepoSource() string {
+	if x != nil {
+		return x.RepoSource
+	}
+	return ""
+}
+
+func (x *BuildLog) GetRepoOwner() string {
+	if x != nil {
+		return x.RepoOwner
+	}
+	return ""
+}
+
+func (x *BuildLog) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *BuildLog) GetRepoBranch() string {
+	if x != nil {
+		return x.RepoBranch
+	}
+	return ""
+}
+
+func (x *BuildLog) GetRepoRevision() string {
+	if x != nil {
+		return x.RepoRevision
+	}
+	return ""
+}
+
+func (x *BuildLog) GetSteps() []*BuildLogStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *BuildLog) GetInsertedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsertedAt
+	}
+	return nil
+}
+
+func (x *BuildLog) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+type BuildLogStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step         string                   `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`
+	Image        *BuildLogStepDockerImage `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	Duration     *durationpb.Duration     `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	LogLines     []*BuildLogLine          `protobuf:"bytes,4,rep,name=log_lines,json=logLines,proto3" json:"log_lines,omitempty"`
+	ExitCode     int64                    `protobuf:"varint,5,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Status       string                   `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	AutoInjected bool                     `protobuf:"varint,7,opt,name=auto_injected,json=autoInjected,proto3" json:"auto_injected,omitempty"`
+	Depth        int32                    `protobuf:"varint,8,opt,name=depth,proto3" json:"depth,omitempty"`
+	RunIndex     int32                    `protobuf:"varint,9,opt,name=run_index,json=runIndex,proto3" json:"run_index,omitempty"`
+	NestedSteps  []*BuildLogStep          `protobuf:"bytes,10,rep,name=nested_steps,json=nestedSteps,proto3" json:"nested_steps,omitempty"`
+	Services     []*BuildLogStep          `protobuf:"bytes,11,rep,name=services,proto3" json:"services,omitempty"`
+}
+
+func (x *BuildLogStep) Reset() {
+	*x = BuildLogStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildLogStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildLogStep) ProtoMessage() {}
+
+func (x *BuildLogStep) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildLogStep.ProtoReflect.Descriptor instead.
+func (*BuildLogStep) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BuildLogStep) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *BuildLogStep) GetImage() *BuildLogStepDockerImage {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *BuildLogStep) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *BuildLogStep) GetLogLines() []*BuildLogLine {
+	if x != nil {
+		return x.LogLines
+	}
+	return nil
+}
+
+func (x *BuildLogStep) GetExitCode() int64 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *BuildLogStep) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BuildLogStep) GetAutoInjected() bool {
+	if x != nil {
+		return x.AutoInjected
+	}
+	return false
+}
+
+func (x *BuildLogStep) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *BuildLogStep) GetRunIndex() int32 {
+	if x != nil {
+		return x.RunIndex
+	}
+	return 0
+}
+
+func (x *BuildLogStep) GetNestedSteps() []*BuildLogStep {
+	if x != nil {
+		return x.NestedSteps
+	}
+	return nil
+}
+
+func (x *BuildLogStep) GetServices() []*BuildLogStep {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type BuildLogStepDockerImage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name                   string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tag                    string               `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	IsPulled               bool                 `protobuf:"varint,3,opt,name=is_pulled,json=isPulled,proto3" json:"is_pulled,omitempty"`
+	ImageSize              int64                `protobuf:"varint,4,opt,name=image_size,json=imageSize,proto3" json:"image_size,omitempty"`
+	PullDuration           *durationpb.Duration `protobuf:"bytes,5,opt,name=pull_duration,json=pullDuration,proto3" json:"pull_duration,omitempty"`
+	Error                  string               `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	IsTrusted              bool                 `protobuf:"varint,7,opt,name=is_trusted,json=isTrusted,proto3" json:"is_trusted,omitempty"`
+	HasInjectedCredentials bool                 `protobuf:"varint,8,opt,name=has_injected_credentials,json=hasInjectedCredentials,proto3" json:"has_injected_credentials,omitempty"`
+}
+
+func (x *BuildLogStepDockerImage) Reset() {
+	*x = BuildLogStepDockerImage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildLogStepDockerImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildLogStepDockerImage) ProtoMessage() {}
+
+func (x *BuildLogStepDockerImage) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildLogStepDockerImage.ProtoReflect.Descriptor instead.
+func (*BuildLogStepDockerImage) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BuildLogStepDockerImage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BuildLogStepDockerImage) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *BuildLogStepDockerImage) GetIsPulled() bool {
+	if x != nil {
+		return x.IsPulled
+	}
+	return false
+}
+
+func (x *BuildLogStepDockerImage) GetImageSize() int64 {
+	if x != nil {
+		return x.ImageSize
+	}
+	return 0
+}
+
+func (x *BuildLogStepDockerImage) GetPullDuration() *durationpb.Duration {
+	if x != nil {
+		return x.PullDuration
+	}
+	return nil
+}
+
+func (x *BuildLogStepDockerImage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BuildLogStepDockerImage) GetIsTrusted() bool {
+	if x != nil {
+		return x.IsTrusted
+	}
+	return false
+}
+
+func (x *BuildLogStepDockerImage) GetHasInjectedCredentials() bool {
+	if x != nil {
+		return x.HasInjectedCredentials
+	}
+	return false
+}
+
+type BuildLogLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	StreamType string                 `protobuf:"bytes,2,opt,name=stream_type,json=streamType,proto3" json:"stream_type,omitempty"`
+	Text       string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	LineNumber int32                  `protobuf:"varint,4,opt,name=line_number,json=lineNumber,proto3" json:"line_number,omitempty"`
+}
+
+func (x *BuildLogLine) Reset() {
+	*x = BuildLogLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BuildLogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildLogLine) ProtoMessage() {}
+
+func (x *BuildLogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildLogLine.ProtoReflect.Descriptor instead.
+func (*BuildLogLine) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BuildLogLine) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *BuildLogLine) GetStreamType() string {
+	if x != nil {
+		return x.StreamType
+	}
+	return ""
+}
+
+func (x *BuildLogLine) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *BuildLogLine) GetLineNumber() int32 {
+	if x != nil {
+		return x.LineNumber
+	}
+	return 0
+}
+
+type ReleaseLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RepoSource string                 `protobuf:"bytes,2,opt,name=repo_source,json=repoSource,proto3" json:"repo_source,omitempty"`
+	RepoOwner  string                 `protobuf:"bytes,3,opt,name=repo_owner,json=repoOwner,proto3" json:"repo_owner,omitempty"`
+	RepoName   string                 `protobuf:"bytes,4,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	ReleaseId  string                 `protobuf:"bytes,5,opt,name=release_id,json=releaseId,proto3" json:"release_id,omitempty"`
+	Steps      []*BuildLogStep        `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	InsertedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=inserted_at,json=insertedAt,proto3" json:"inserted_at,omitempty"`
+}
+
+func (x *ReleaseLog) Reset() {
+	*x = ReleaseLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseLog) ProtoMessage() {}
+
+func (x *ReleaseLog) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseLog.ProtoReflect.Descriptor instead.
+func (*ReleaseLog) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReleaseLog) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReleaseLog) GetRepoSource() string {
+	if x != nil {
+		return x.RepoSource
+	}
+	return ""
+}
+
+func (x *ReleaseLog) GetRepoOwner() string {
+	if x != nil {
+		return x.RepoOwner
+	}
+	return ""
+}
+
+func (x *ReleaseLog) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *ReleaseLog) GetReleaseId() string {
+	if x != nil {
+		return x.ReleaseId
+	}
+	return ""
+}
+
+func (x *ReleaseLog) GetSteps() []*BuildLogStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *ReleaseLog) GetInsertedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsertedAt
+	}
+	return nil
+}
+
+type BotLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RepoSource string                 `protobuf:"bytes,2,opt,name=repo_source,json=repoSource,proto3" json:"repo_source,omitempty"`
+	RepoOwner  string                 `protobuf:"bytes,3,opt,name=repo_owner,json=repoOwner,proto3" json:"repo_owner,omitempty"`
+	RepoName   string                 `protobuf:"bytes,4,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	BotId      string                 `protobuf:"bytes,5,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	Steps      []*BuildLogStep        `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	InsertedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=inserted_at,json=insertedAt,proto3" json:"inserted_at,omitempty"`
+}
+
+func (x *BotLog) Reset() {
+	*x = BotLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BotLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotLog) ProtoMessage() {}
+
+func (x *BotLog) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotLog.ProtoReflect.Descriptor instead.
+func (*BotLog) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BotLog) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BotLog) GetRepoSource() string {
+	if x != nil {
+		return x.RepoSource
+	}
+	return ""
+}
+
+func (x *BotLog) GetRepoOwner() string {
+	if x != nil {
+		return x.RepoOwner
+	}
+	return ""
+}
+
+func (x *BotLog) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *BotLog) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *BotLog) GetSteps() []*BuildLogStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *BotLog) GetInsertedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsertedAt
+	}
+	return nil
+}
+
+type TailLogLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step         string                   `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`
+	ParentStage  string                   `protobuf:"bytes,2,opt,name=parent_stage,json=parentStage,proto3" json:"parent_stage,omitempty"`
+	Type         string                   `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Depth        int32                    `protobuf:"varint,4,opt,name=depth,proto3" json:"depth,omitempty"`
+	RunIndex     int32                    `protobuf:"varint,5,opt,name=run_index,json=runIndex,proto3" json:"run_index,omitempty"`
+	LogLine      *BuildLogLine            `protobuf:"bytes,6,opt,name=log_line,json=logLine,proto3" json:"log_line,omitempty"`
+	Image        *BuildLogStepDockerImage `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	Duration     *durationpb.Duration     `protobuf:"bytes,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	ExitCode     *int64                   `protobuf:"varint,9,opt,name=exit_code,json=exitCode,proto3,oneof" json:"exit_code,omitempty"`
+	Status       *string                  `protobuf:"bytes,10,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	AutoInjected *bool                    `protobuf:"varint,11,opt,name=auto_injected,json=autoInjected,proto3,oneof" json:"auto_injected,omitempty"`
+}
+
+func (x *TailLogLine) Reset() {
+	*x = TailLogLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TailLogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailLogLine) ProtoMessage() {}
+
+func (x *TailLogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailLogLine.ProtoReflect.Descriptor instead.
+func (*TailLogLine) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TailLogLine) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *TailLogLine) GetParentStage() string {
+	if x != nil {
+		return x.ParentStage
+	}
+	return ""
+}
+
+func (x *TailLogLine) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TailLogLine) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *TailLogLine) GetRunIndex() int32 {
+	if x != nil {
+		return x.RunIndex
+	}
+	return 0
+}
+
+func (x *TailLogLine) GetLogLine() *BuildLogLine {
+	if x != nil {
+		return x.LogLine
+	}
+	return nil
+}
+
+func (x *TailLogLine) GetImage() *BuildLogStepDockerImage {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *TailLogLine) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *TailLogLine) GetExitCode() int64 {
+	if x != nil && x.ExitCode != nil {
+		return *x.ExitCode
+	}
+	return 0
+}
+
+func (x *TailLogLine) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *TailLogLine) GetAutoInjected() bool {
+	if x != nil && x.AutoInjected != nil {
+		return *x.AutoInjected
+	}
+	return false
+}
+
+type JobTailLogLine struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RepoSource string `protobuf:"bytes,1,opt,name=repo_source,json=repoSource,proto3" json:"repo_source,omitempty"`
+	RepoOwner  string `protobuf:"bytes,2,opt,name=repo_owner,json=repoOwner,proto3" json:"repo_owner,omitempty"`
+	RepoName   string `protobuf:"bytes,3,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	// build, release or bot id depending on the rpc
+	JobId       string       `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	TailLogLine *TailLogLine `protobuf:"bytes,5,opt,name=tail_log_line,json=tailLogLine,proto3" json:"tail_log_line,omitempty"`
+}
+
+func (x *JobTailLogLine) Reset() {
+	*x = JobTailLogLine{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobTailLogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobTailLogLine) ProtoMessage() {}
+
+func (x *JobTailLogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobTailLogLine.ProtoReflect.Descriptor instead.
+func (*JobTailLogLine) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JobTailLogLine) GetRepoSource() string {
+	if x != nil {
+		return x.RepoSource
+	}
+	return ""
+}
+
+func (x *JobTailLogLine) GetRepoOwner() string {
+	if x != nil {
+		return x.RepoOwner
+	}
+	return ""
+}
+
+func (x *JobTailLogLine) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *JobTailLogLine) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobTailLogLine) GetTailLogLine() *TailLogLine {
+	if x != nil {
+		return x.TailLogLine
+	}
+	return nil
+}
+
+type PushLogLinesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceivedLines int64 `protobuf:"varint,1,opt,name=received_lines,json=receivedLines,proto3" json:"received_lines,omitempty"`
+}
+
+func (x *PushLogLinesResponse) Reset() {
+	*x = PushLogLinesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushLogLinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushLogLinesResponse) ProtoMessage() {}
+
+func (x *PushLogLinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushLogLinesResponse.ProtoReflect.Descriptor instead.
+func (*PushLogLinesResponse) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PushLogLinesResponse) GetReceivedLines() int64 {
+	if x != nil {
+		return x.ReceivedLines
+	}
+	return 0
+}
+
+type TailJobLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RepoSource string `protobuf:"bytes,1,opt,name=repo_source,json=repoSource,proto3" json:"repo_source,omitempty"`
+	RepoOwner  string `protobuf:"bytes,2,opt,name=repo_owner,json=repoOwner,proto3" json:"repo_owner,omitempty"`
+	RepoName   string `protobuf:"bytes,3,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	// build, release or bot id depending on the rpc
+	JobId string `protobuf:"bytes,4,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *TailJobLogRequest) Reset() {
+	*x = TailJobLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_estafette_ci_api_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TailJobLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailJobLogRequest) ProtoMessage() {}
+
+func (x *TailJobLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_estafette_ci_api_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailJobLogRequest.ProtoReflect.Descriptor instead.
+func (*TailJobLogRequest) Descriptor() ([]byte, []int) {
+	return file_estafette_ci_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TailJobLogRequest) GetRepoSource() string {
+	if x != nil {
+		return x.RepoSource
+	}
+	return ""
+}
+
+func (x *TailJobLogRequest) GetRepoOwner() string {
+	if x != nil {
+		return x.RepoOwner
+	}
+	return ""
+}
+
+func (x *TailJobLogRequest) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *TailJobLogRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+var File_estafette_ci_api_proto protoreflect.FileDescriptor
+
+var file_estafette_ci_api_proto_rawDesc = []byte{
+	0x0a, 0x16, 0x65, 0x73, 0x74, 0x61, 0x66, 0x65, 0x74, 0x74, 0x65, 0x5f, 0x63, 0x69, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e,
+	0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x1a,
+	0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x02,
+	0x0a, 0x08, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x70, 0x6f, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x65, 0x70, 0x6f, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72,
+	0x65, 0x70, 0x6f, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x72, 0x65, 0x70, 0x6f, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65,
+	0x70, 0x6f, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x70, 0x6f, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
+	0x70, 0x6f, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f,
+	0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a,
+	0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65,
+	0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x64,
+	0x22, 0xf7, 0x03, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x44, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e,
+	0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65, 0x70, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65,
+	0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x42, 0x75,
+	0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x4c,
+	0x69, 0x6e, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x75, 0x74,
+	0x6f, 0x5f, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x61, 0x75, 0x74, 0x6f, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64,
+	0x65, 0x70, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x75, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x75, 0x6e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x46, 0x0a, 0x0c, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x65, 0x70,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e,
+	0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e,
+	0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65, 0x70, 0x52, 0x0b, 0x6e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x3f, 0x0a, 0x08, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x69,
+	0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65, 0x70,
+	0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0xaa, 0x02, 0x0a, 0x17, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65, 0x70, 0x44, 0x6f, 0x63, 0x6b, 0x65,
+	0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x1b, 0x0a, 0x09,
+	0x69, 0x73, 0x5f, 0x70, 0x75, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x69, 0x73, 0x50, 0x75, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x69,
+	0x6d, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x70, 0x75, 0x6c, 0x6c,
+	0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x70, 0x75, 0x6c, 0x6c,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a,
+	0x18, 0x68, 0x61, 0x73, 0x5f, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x16, 0x68, 0x61, 0x73, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x22, 0x9e, 0x01, 0x0a, 0x0c, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6c, 0x69,
+	0x6e, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x90, 0x02, 0x0a, 0x0a, 0x52, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65,
+	0x70, 0x6f, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f,
+	0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x70, 0x6f, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x3b,
+	0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x84, 0x02, 0x0a, 0x06,
+	0x42, 0x6f, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70,
+	0x6f, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x5f,
+	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x70,
+	0x6f, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x74,
+	0x65, 0x70, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x69, 0x70, 0x6c,
+	0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05,
+	0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x22, 0xdc, 0x03, 0x0a, 0x0b, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x69,
+	0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x75, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x75, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x3e, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64,
+	0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65,
+	0x12, 0x44, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2e, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67,
+	0x53, 0x74, 0x65, 0x70, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a,
+	0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03,
+	0x48, 0x00, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12,
+	0x1b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x01, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d,
+	0x61, 0x75, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x02, 0x52, 0x0c, 0x61, 0x75, 0x74, 0x6f, 0x49, 0x6e, 0x6a, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42,
+	0x10, 0x0a, 0x0e, 0x5f, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x22, 0xcc, 0x01, 0x0a, 0x0e, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67,
+	0x4c, 0x69, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x46, 0x0a, 0x0d, 0x74, 0x61, 0x69, 0x6c,
+	0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x4c,
+	0x69, 0x6e, 0x65, 0x52, 0x0b, 0x74, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65,
+	0x22, 0x3d, 0x0a, 0x14, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x22,
+	0x87, 0x01, 0x0a, 0x11, 0x54, 0x61, 0x69, 0x6c, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6f,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x32, 0x8d, 0x07, 0x0a, 0x0d, 0x5a, 0x69,
+	0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x43, 0x69, 0x41, 0x70, 0x69, 0x12, 0x54, 0x0a, 0x17, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x42, 0x75, 0x69,
+	0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1f, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65,
+	0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x42,
+	0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x00, 0x12, 0x58, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x21,
+	0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f,
+	0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x15, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x42, 0x6f, 0x74,
+	0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1d, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e,
+	0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x42, 0x6f, 0x74,
+	0x4c, 0x6f, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x6b, 0x0a,
+	0x11, 0x50, 0x75, 0x73, 0x68, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e,
+	0x65, 0x73, 0x12, 0x25, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x61,
+	0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x1a, 0x2b, 0x2e, 0x7a, 0x69, 0x70, 0x6c,
+	0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x73, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x6d, 0x0a, 0x13, 0x50, 0x75,
+	0x73, 0x68, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65,
+	0x73, 0x12, 0x25, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x69,
+	0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x1a, 0x2b, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69,
+	0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73,
+	0x2e, 0x50, 0x75, 0x73, 0x68, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x69, 0x0a, 0x0f, 0x50, 0x75, 0x73,
+	0x68, 0x42, 0x6f, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x7a,
+	0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x73, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x4c,
+	0x69, 0x6e, 0x65, 0x1a, 0x2b, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63,
+	0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x75, 0x73, 0x68,
+	0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x28, 0x01, 0x12, 0x65, 0x0a, 0x11, 0x54, 0x61, 0x69, 0x6c, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x7a, 0x69, 0x70, 0x6c,
+	0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x73, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63,
+	0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x54, 0x61, 0x69, 0x6c,
+	0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x67, 0x0a, 0x13, 0x54,
+	0x61, 0x69, 0x6c, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e,
+	0x65, 0x73, 0x12, 0x28, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69,
+	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x4a,
+	0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x7a,
+	0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x73, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65,
+	0x22, 0x00, 0x30, 0x01, 0x12, 0x63, 0x0a, 0x0f, 0x54, 0x61, 0x69, 0x6c, 0x42, 0x6f, 0x74, 0x4c,
+	0x6f, 0x67, 0x4c, 0x69, 0x6e, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e,
+	0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e,
+	0x54, 0x61, 0x69, 0x6c, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x22, 0x2e, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2e, 0x63, 0x69, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2e, 0x54, 0x61, 0x69, 0x6c, 0x4c, 0x6f,
+	0x67, 0x4c, 0x69, 0x6e, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65,
+	0x63, 0x69, 0x2f, 0x7a, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x65, 0x2d, 0x63, 0x69, 0x2d, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_estafette_ci_api_proto_rawDescOnce sync.Once
+	file_estafette_ci_api_proto_rawDescData = file_estafette_ci_api_proto_rawDesc
+)
+
+func file_estafette_ci_api_proto_rawDescGZIP() []byte {
+	file_estafette_ci_api_proto_rawDescOnce.Do(func() {
+		file_estafette_ci_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_estafette_ci_api_proto_rawDescData)
+	})
+	return file_estafette_ci_api_proto_rawDescData
+}
+
+var file_estafette_ci_api_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_estafette_ci_api_proto_goTypes = []any{
+	(*BuildLog)(nil),                // 0: ziplinee.ci.contracts.BuildLog
+	(*BuildLogStep)(nil),            // 1: ziplinee.ci.contracts.BuildLogStep
+	(*BuildLogStepDockerImage)(nil), // 2: ziplinee.ci.contracts.BuildLogStepDockerImage
+	(*BuildLogLine)(nil),            // 3: ziplinee.ci.contracts.BuildLogLine
+	(*ReleaseLog)(nil),              // 4: ziplinee.ci.contracts.ReleaseLog
+	(*BotLog)(nil),                  // 5: ziplinee.ci.contracts.BotLog
+	(*TailLogLine)(nil),             // 6: ziplinee.ci.contracts.TailLogLine
+	(*JobTailLogLine)(nil),          // 7: ziplinee.ci.contracts.JobTailLogLine
+	(*PushLogLinesResponse)(nil),    // 8: ziplinee.ci.contracts.PushLogLinesResponse
+	(*TailJobLogRequest)(nil),       // 9: ziplinee.ci.contracts.TailJobLogRequest
+	(*timestamppb.Timestamp)(nil),   // 10: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),     // 11: google.protobuf.Duration
+	(*emptypb.Empty)(nil),           // 12: google.protobuf.Empty
+}
+var file_estafette_ci_api_proto_depIdxs = []int32{
+	1,  // 0: ziplinee.ci.contracts.BuildLog.steps:type_name -> ziplinee.ci.contracts.BuildLogStep
+	10, // 1: ziplinee.ci.contracts.BuildLog.inserted_at:type_name -> google.protobuf.Timestamp
+	2,  // 2: ziplinee.ci.contracts.BuildLogStep.image:type_name -> ziplinee.ci.contracts.BuildLogStepDockerImage
+	11, // 3: ziplinee.ci.contracts.BuildLogStep.duration:type_name -> google.protobuf.Duration
+	3,  // 4: ziplinee.ci.contracts.BuildLogStep.log_lines:type_name -> ziplinee.ci.contracts.BuildLogLine
+	1,  // 5: ziplinee.ci.contracts.BuildLogStep.nested_steps:type_name -> ziplinee.ci.contracts.BuildLogStep
+	1,  // 6: ziplinee.ci.contracts.BuildLogStep.services:type_name -> ziplinee.ci.contracts.BuildLogStep
+	11, // 7: ziplinee.ci.contracts.BuildLogStepDockerImage.pull_duration:type_name -> google.protobuf.Duration
+	10, // 8: ziplinee.ci.contracts.BuildLogLine.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 9: ziplinee.ci.contracts.ReleaseLog.steps:type_name -> ziplinee.ci.contracts.BuildLogStep
+	10, // 10: ziplinee.ci.contracts.ReleaseLog.inserted_at:type_name -> google.protobuf.Timestamp
+	1,  // 11: ziplinee.ci.contracts.BotLog.steps:type_name -> ziplinee.ci.contracts.BuildLogStep
+	10, // 12: ziplinee.ci.contracts.BotLog.inserted_at:type_name -> google.protobuf.Timestamp
+	3,  // 13: ziplinee.ci.contracts.TailLogLine.log_line:type_name -> ziplinee.ci.contracts.BuildLogLine
+	2,  // 14: ziplinee.ci.contracts.TailLogLine.image:type_name -> ziplinee.ci.contracts.BuildLogStepDockerImage
+	11, // 15: ziplinee.ci.contracts.TailLogLine.duration:type_name -> google.protobuf.Duration
+	6,  // 16: ziplinee.ci.contracts.JobTailLogLine.tail_log_line:type_name -> ziplinee.ci.contracts.TailLogLine
+	0,  // 17: ziplinee.ci.contracts.ZiplineeCiApi.CreatePipelineBuildLogs:input_type -> ziplinee.ci.contracts.BuildLog
+	4,  // 18: ziplinee.ci.contracts.ZiplineeCiApi.CreatePipelineReleaseLogs:input_type -> ziplinee.ci.contracts.ReleaseLog
+	5,  // 19: ziplinee.ci.contracts.ZiplineeCiApi.CreatePipelineBotLogs:input_type -> ziplinee.ci.contracts.BotLog
+	7,  // 20: ziplinee.ci.contracts.ZiplineeCiApi.PushBuildLogLines:input_type -> ziplinee.ci.contracts.JobTailLogLine
+	7,  // 21: ziplinee.ci.contracts.ZiplineeCiApi.PushReleaseLogLines:input_type -> ziplinee.ci.contracts.JobTailLogLine
+	7,  // 22: ziplinee.ci.contracts.ZiplineeCiApi.PushBotLogLines:input_type -> ziplinee.ci.contracts.JobTailLogLine
+	9,  // 23: ziplinee.ci.contracts.ZiplineeCiApi.TailBuildLogLines:input_type -> ziplinee.ci.contracts.TailJobLogRequest
+	9,  // 24: ziplinee.ci.contracts.ZiplineeCiApi.TailReleaseLogLines:input_type -> ziplinee.ci.contracts.TailJobLogRequest
+	9,  // 25: ziplinee.ci.contracts.ZiplineeCiApi.TailBotLogLines:input_type -> ziplinee.ci.contracts.TailJobLogRequest
+	12, // 26: ziplinee.ci.contracts.ZiplineeCiApi.CreatePipelineBuildLogs:output_type -> google.protobuf.Empty
+	12, // 27: ziplinee.ci.contracts.ZiplineeCiApi.CreatePipelineReleaseLogs:output_type -> google.protobuf.Empty
+	12, // 28: ziplinee.ci.contracts.ZiplineeCiApi.CreatePipelineBotLogs:output_type -> google.protobuf.Empty
+	8,  // 29: ziplinee.ci.contracts.ZiplineeCiApi.PushBuildLogLines:output_type -> ziplinee.ci.contracts.PushLogLinesResponse
+	8,  // 30: ziplinee.ci.contracts.ZiplineeCiApi.PushReleaseLogLines:output_type -> ziplinee.ci.contracts.PushLogLinesResponse
+	8,  // 31: ziplinee.ci.contracts.ZiplineeCiApi.PushBotLogLines:output_type -> ziplinee.ci.contracts.PushLogLinesResponse
+	6,  // 32: ziplinee.ci.contracts.ZiplineeCiApi.TailBuildLogLines:output_type -> ziplinee.ci.contracts.TailLogLine
+	6,  // 33: ziplinee.ci.contracts.ZiplineeCiApi.TailReleaseLogLines:output_type -> ziplinee.ci.contracts.TailLogLine
+	6,  // 34: ziplinee.ci.contracts.ZiplineeCiApi.TailBotLogLines:output_type -> ziplinee.ci.contracts.TailLogLine
+	26, // [26:35] is the sub-list for method output_type
+	17, // [17:26] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_estafette_ci_api_proto_init() }
+func file_estafette_ci_api_proto_init() {
+	if File_estafette_ci_api_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_estafette_ci_api_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*BuildLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*BuildLogStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*BuildLogStepDockerImage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*BuildLogLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ReleaseLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*BotLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*TailLogLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*JobTailLogLine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*PushLogLinesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_estafette_ci_api_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*TailJobLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_estafette_ci_api_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_estafette_ci_api_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_estafette_ci_api_proto_goTypes,
+		DependencyIndexes: file_estafette_ci_api_proto_depIdxs,
+		MessageInfos:      file_estafette_ci_api_proto_msgTypes,
+	}.Build()
+	File_estafette_ci_api_proto = out.File
+	file_estafette_ci_api_proto_rawDesc = nil
+	file_estafette_ci_api_proto_goTypes = nil
+	file_estafette_ci_api_proto_depIdxs = nil
+}
@@ -0,0 +1,419 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: estafette_ci_api.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ZiplineeCiApi_CreatePipelineBuildLogs_FullMethodName   = "/ziplinee.ci.contracts.ZiplineeCiApi/CreatePipelineBuildLogs"
+	ZiplineeCiApi_CreatePipelineReleaseLogs_FullMethodName = "/ziplinee.ci.contracts.ZiplineeCiApi/CreatePipelineReleaseLogs"
+	ZiplineeCiApi_CreatePipelineBotLogs_FullMethodName     = "/ziplinee.ci.contracts.ZiplineeCiApi/CreatePipelineBotLogs"
+	ZiplineeCiApi_PushBuildLogLines_FullMethodName         = "/ziplinee.ci.contracts.ZiplineeCiApi/PushBuildLogLines"
+	ZiplineeCiApi_PushReleaseLogLines_FullMethodName       = "/ziplinee.ci.contracts.ZiplineeCiApi/PushReleaseLogLines"
+	ZiplineeCiApi_PushBotLogLines_FullMethodName           = "/ziplinee.ci.contracts.ZiplineeCiApi/PushBotLogLines"
+	ZiplineeCiApi_TailBuildLogLines_FullMethodName         = "/ziplinee.ci.contracts.ZiplineeCiApi/TailBuildLogLines"
+	ZiplineeCiApi_TailReleaseLogLines_FullMethodName       = "/ziplinee.ci.contracts.ZiplineeCiApi/TailReleaseLogLines"
+	ZiplineeCiApi_TailBotLogLines_FullMethodName           = "/ziplinee.ci.contracts.ZiplineeCiApi/TailBotLogLines"
+)
+
+// ZiplineeCiApiClient is the client API for ZiplineeCiApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ZiplineeCiApiClient interface {
+	CreatePipelineBuildLogs(ctx context.Context, in *BuildLog, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreatePipelineReleaseLogs(ctx context.Context, in *ReleaseLog, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreatePipelineBotLogs(ctx context.Context, in *BotLog, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// used by the builder to push log lines while a job is running; closing the stream marks the job as finished
+	PushBuildLogLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse], error)
+	PushReleaseLogLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse], error)
+	PushBotLogLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse], error)
+	// used by the web ui to follow a running job; the stream ends when the job is finished
+	TailBuildLogLines(ctx context.Context, in *TailJobLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogLine], error)
+	TailReleaseLogLines(ctx context.Context, in *TailJobLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogLine], error)
+	TailBotLogLines(ctx context.Context, in *TailJobLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogLine], error)
+}
+
+type ziplineeCiApiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewZiplineeCiApiClient(cc grpc.ClientConnInterface) ZiplineeCiApiClient {
+	return &ziplineeCiApiClient{cc}
+}
+
+func (c *ziplineeCiApiClient) CreatePipelineBuildLogs(ctx context.Context, in *BuildLog, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ZiplineeCiApi_CreatePipelineBuildLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ziplineeCiApiClient) CreatePipelineReleaseLogs(ctx context.Context, in *ReleaseLog, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ZiplineeCiApi_CreatePipelineReleaseLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ziplineeCiApiClient) CreatePipelineBotLogs(ctx context.Context, in *BotLog, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ZiplineeCiApi_CreatePipelineBotLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ziplineeCiApiClient) PushBuildLogLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ZiplineeCiApi_ServiceDesc.Streams[0], ZiplineeCiApi_PushBuildLogLines_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JobTailLogLine, PushLogLinesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_PushBuildLogLinesClient = grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse]
+
+func (c *ziplineeCiApiClient) PushReleaseLogLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ZiplineeCiApi_ServiceDesc.Streams[1], ZiplineeCiApi_PushReleaseLogLines_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JobTailLogLine, PushLogLinesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_PushReleaseLogLinesClient = grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse]
+
+func (c *ziplineeCiApiClient) PushBotLogLines(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ZiplineeCiApi_ServiceDesc.Streams[2], ZiplineeCiApi_PushBotLogLines_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JobTailLogLine, PushLogLinesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_PushBotLogLinesClient = grpc.ClientStreamingClient[JobTailLogLine, PushLogLinesResponse]
+
+func (c *ziplineeCiApiClient) TailBuildLogLines(ctx context.Context, in *TailJobLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ZiplineeCiApi_ServiceDesc.Streams[3], ZiplineeCiApi_TailBuildLogLines_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailJobLogRequest, TailLogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_TailBuildLogLinesClient = grpc.ServerStreamingClient[TailLogLine]
+
+func (c *ziplineeCiApiClient) TailReleaseLogLines(ctx context.Context, in *TailJobLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ZiplineeCiApi_ServiceDesc.Streams[4], ZiplineeCiApi_TailReleaseLogLines_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailJobLogRequest, TailLogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_TailReleaseLogLinesClient = grpc.ServerStreamingClient[TailLogLine]
+
+func (c *ziplineeCiApiClient) TailBotLogLines(ctx context.Context, in *TailJobLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ZiplineeCiApi_ServiceDesc.Streams[5], ZiplineeCiApi_TailBotLogLines_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailJobLogRequest, TailLogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_TailBotLogLinesClient = grpc.ServerStreamingClient[TailLogLine]
+
+// ZiplineeCiApiServer is the server API for ZiplineeCiApi service.
+// All implementations must embed UnimplementedZiplineeCiApiServer
+// for forward compatibility.
+type ZiplineeCiApiServer interface {
+	CreatePipelineBuildLogs(context.Context, *BuildLog) (*emptypb.Empty, error)
+	CreatePipelineReleaseLogs(context.Context, *ReleaseLog) (*emptypb.Empty, error)
+	CreatePipelineBotLogs(context.Context, *BotLog) (*emptypb.Empty, error)
+	// used by the builder to push log lines while a job is running; closing the stream marks the job as finished
+	PushBuildLogLines(grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error
+	PushReleaseLogLines(grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error
+	PushBotLogLines(grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error
+	// used by the web ui to follow a running job; the stream ends when the job is finished
+	TailBuildLogLines(*TailJobLogRequest, grpc.ServerStreamingServer[TailLogLine]) error
+	TailReleaseLogLines(*TailJobLogRequest, grpc.ServerStreamingServer[TailLogLine]) error
+	TailBotLogLines(*TailJobLogRequest, grpc.ServerStreamingServer[TailLogLine]) error
+	mustEmbedUnimplementedZiplineeCiApiServer()
+}
+
+// UnimplementedZiplineeCiApiServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedZiplineeCiApiServer struct{}
+
+func (UnimplementedZiplineeCiApiServer) CreatePipelineBuildLogs(context.Context, *BuildLog) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePipelineBuildLogs not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) CreatePipelineReleaseLogs(context.Context, *ReleaseLog) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePipelineReleaseLogs not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) CreatePipelineBotLogs(context.Context, *BotLog) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePipelineBotLogs not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) PushBuildLogLines(grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PushBuildLogLines not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) PushReleaseLogLines(grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PushReleaseLogLines not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) PushBotLogLines(grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PushBotLogLines not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) TailBuildLogLines(*TailJobLogRequest, grpc.ServerStreamingServer[TailLogLine]) error {
+	return status.Errorf(codes.Unimplemented, "method TailBuildLogLines not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) TailReleaseLogLines(*TailJobLogRequest, grpc.ServerStreamingServer[TailLogLine]) error {
+	return status.Errorf(codes.Unimplemented, "method TailReleaseLogLines not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) TailBotLogLines(*TailJobLogRequest, grpc.ServerStreamingServer[TailLogLine]) error {
+	return status.Errorf(codes.Unimplemented, "method TailBotLogLines not implemented")
+}
+func (UnimplementedZiplineeCiApiServer) mustEmbedUnimplementedZiplineeCiApiServer() {}
+func (UnimplementedZiplineeCiApiServer) testEmbeddedByValue()                       {}
+
+// UnsafeZiplineeCiApiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ZiplineeCiApiServer will
+// result in compilation errors.
+type UnsafeZiplineeCiApiServer interface {
+	mustEmbedUnimplementedZiplineeCiApiServer()
+}
+
+func RegisterZiplineeCiApiServer(s grpc.ServiceRegistrar, srv ZiplineeCiApiServer) {
+	// If the following call pancis, it indicates UnimplementedZiplineeCiApiServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ZiplineeCiApi_ServiceDesc, srv)
+}
+
+func _ZiplineeCiApi_CreatePipelineBuildLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZiplineeCiApiServer).CreatePipelineBuildLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ZiplineeCiApi_CreatePipelineBuildLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZiplineeCiApiServer).CreatePipelineBuildLogs(ctx, req.(*BuildLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZiplineeCiApi_CreatePipelineReleaseLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZiplineeCiApiServer).CreatePipelineReleaseLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ZiplineeCiApi_CreatePipelineReleaseLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZiplineeCiApiServer).CreatePipelineReleaseLogs(ctx, req.(*ReleaseLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZiplineeCiApi_CreatePipelineBotLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BotLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZiplineeCiApiServer).CreatePipelineBotLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ZiplineeCiApi_CreatePipelineBotLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZiplineeCiApiServer).CreatePipelineBotLogs(ctx, req.(*BotLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ZiplineeCiApi_PushBuildLogLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ZiplineeCiApiServer).PushBuildLogLines(&grpc.GenericServerStream[JobTailLogLine, PushLogLinesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_PushBuildLogLinesServer = grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]
+
+func _ZiplineeCiApi_PushReleaseLogLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ZiplineeCiApiServer).PushReleaseLogLines(&grpc.GenericServerStream[JobTailLogLine, PushLogLinesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_PushReleaseLogLinesServer = grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]
+
+func _ZiplineeCiApi_PushBotLogLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ZiplineeCiApiServer).PushBotLogLines(&grpc.GenericServerStream[JobTailLogLine, PushLogLinesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_PushBotLogLinesServer = grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]
+
+func _ZiplineeCiApi_TailBuildLogLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailJobLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZiplineeCiApiServer).TailBuildLogLines(m, &grpc.GenericServerStream[TailJobLogRequest, TailLogLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_TailBuildLogLinesServer = grpc.ServerStreamingServer[TailLogLine]
+
+func _ZiplineeCiApi_TailReleaseLogLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailJobLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZiplineeCiApiServer).TailReleaseLogLines(m, &grpc.GenericServerStream[TailJobLogRequest, TailLogLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_TailReleaseLogLinesServer = grpc.ServerStreamingServer[TailLogLine]
+
+func _ZiplineeCiApi_TailBotLogLines_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailJobLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ZiplineeCiApiServer).TailBotLogLines(m, &grpc.GenericServerStream[TailJobLogRequest, TailLogLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ZiplineeCiApi_TailBotLogLinesServer = grpc.ServerStreamingServer[TailLogLine]
+
+// ZiplineeCiApi_ServiceDesc is the grpc.ServiceDesc for ZiplineeCiApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ZiplineeCiApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ziplinee.ci.contracts.ZiplineeCiApi",
+	HandlerType: (*ZiplineeCiApiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePipelineBuildLogs",
+			Handler:    _ZiplineeCiApi_CreatePipelineBuildLogs_Handler,
+		},
+		{
+			MethodName: "CreatePipelineReleaseLogs",
+			Handler:    _ZiplineeCiApi_CreatePipelineReleaseLogs_Handler,
+		},
+		{
+			MethodName: "CreatePipelineBotLogs",
+			Handler:    _ZiplineeCiApi_CreatePipelineBotLogs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PushBuildLogLines",
+			Handler:       _ZiplineeCiApi_PushBuildLogLines_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PushReleaseLogLines",
+			Handler:       _ZiplineeCiApi_PushReleaseLogLines_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PushBotLogLines",
+			Handler:       _ZiplineeCiApi_PushBotLogLines_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TailBuildLogLines",
+			Handler:       _ZiplineeCiApi_TailBuildLogLines_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TailReleaseLogLines",
+			Handler:       _ZiplineeCiApi_TailReleaseLogLines_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TailBotLogLines",
+			Handler:       _ZiplineeCiApi_TailBotLogLines_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "estafette_ci_api.proto",
+}
@@ -0,0 +1,288 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DefaultInMemoryFinishedJobs is the number of finished jobs an InMemoryServer keeps the pushed lines of for late tailing clients
+const DefaultInMemoryFinishedJobs = 100
+
+// InMemoryServer is a reference implementation of the ZiplineeCiApi service that keeps all logs in memory; it's meant for tests and local development
+type InMemoryServer struct {
+	UnimplementedZiplineeCiApiServer
+
+	mu          sync.Mutex
+	buildLogs   map[string]*BuildLog
+	releaseLogs map[string]*ReleaseLog
+	botLogs     map[string]*BotLog
+	jobs        map[string]*inMemoryJob
+	// jobsUpdated is closed and replaced whenever a job is added, to wake up clients tailing a job that doesn't exist yet
+	jobsUpdated chan struct{}
+	// finishedJobs holds the keys of finished jobs, oldest first; beyond maxFinishedJobs the oldest are evicted
+	finishedJobs    []string
+	maxFinishedJobs int
+}
+
+// inMemoryJob holds the lines pushed for a running job; updated is closed and replaced whenever lines are added or the job finishes
+type inMemoryJob struct {
+	lines    []*TailLogLine
+	finished bool
+	updated  chan struct{}
+}
+
+// NewInMemoryServer returns an empty InMemoryServer
+func NewInMemoryServer() *InMemoryServer {
+	return &InMemoryServer{
+		buildLogs:       map[string]*BuildLog{},
+		releaseLogs:     map[string]*ReleaseLog{},
+		botLogs:         map[string]*BotLog{},
+		jobs:            map[string]*inMemoryJob{},
+		jobsUpdated:     make(chan struct{}),
+		maxFinishedJobs: DefaultInMemoryFinishedJobs,
+	}
+}
+
+// CreatePipelineBuildLogs stores a finished build log by its build id
+func (s *InMemoryServer) CreatePipelineBuildLogs(ctx context.Context, buildLog *BuildLog) (*emptypb.Empty, error) {
+	if buildLog.GetBuildId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "build_id needs to be set")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buildLogs[getInMemoryJobKey(contracts.JobTypeBuild, buildLog.GetRepoSource(), buildLog.GetRepoOwner(), buildLog.GetRepoName(), buildLog.GetBuildId())] = buildLog
+
+	return &emptypb.Empty{}, nil
+}
+
+// CreatePipelineReleaseLogs stores a finished release log by its release id
+func (s *InMemoryServer) CreatePipelineReleaseLogs(ctx context.Context, releaseLog *ReleaseLog) (*emptypb.Empty, error) {
+	if releaseLog.GetReleaseId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "release_id needs to be set")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releaseLogs[getInMemoryJobKey(contracts.JobTypeRelease, releaseLog.GetRepoSource(), releaseLog.GetRepoOwner(), releaseLog.GetRepoName(), releaseLog.GetReleaseId())] = releaseLog
+
+	return &emptypb.Empty{}, nil
+}
+
+// CreatePipelineBotLogs stores a finished bot log by its bot id
+func (s *InMemoryServer) CreatePipelineBotLogs(ctx context.Context, botLog *BotLog) (*emptypb.Empty, error) {
+	if botLog.GetBotId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "bot_id needs to be set")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.botLogs[getInMemoryJobKey(contracts.JobTypeBot, botLog.GetRepoSource(), botLog.GetRepoOwner(), botLog.GetRepoName(), botLog.GetBotId())] = botLog
+
+	return &emptypb.Empty{}, nil
+}
+
+// PushBuildLogLines receives the log lines of a running build
+func (s *InMemoryServer) PushBuildLogLines(stream grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error {
+	return s.pushLogLines(contracts.JobTypeBuild, stream)
+}
+
+// PushReleaseLogLines receives the log lines of a running release
+func (s *InMemoryServer) PushReleaseLogLines(stream grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error {
+	return s.pushLogLines(contracts.JobTypeRelease, stream)
+}
+
+// PushBotLogLines receives the log lines of a running bot
+func (s *InMemoryServer) PushBotLogLines(stream grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error {
+	return s.pushLogLines(contracts.JobTypeBot, stream)
+}
+
+// TailBuildLogLines sends all log lines of a build pushed so far and keeps sending new ones until the build finishes
+func (s *InMemoryServer) TailBuildLogLines(request *TailJobLogRequest, stream grpc.ServerStreamingServer[TailLogLine]) error {
+	return s.tailLogLines(contracts.JobTypeBuild, request, stream)
+}
+
+// TailReleaseLogLines sends all log lines of a release pushed so far and keeps sending new ones until the release finishes
+func (s *InMemoryServer) TailReleaseLogLines(request *TailJobLogRequest, stream grpc.ServerStreamingServer[TailLogLine]) error {
+	return s.tailLogLines(contracts.JobTypeRelease, request, stream)
+}
+
+// TailBotLogLines sends all log lines of a bot pushed so far and keeps sending new ones until the bot finishes
+func (s *InMemoryServer) TailBotLogLines(request *TailJobLogRequest, stream grpc.ServerStreamingServer[TailLogLine]) error {
+	return s.tailLogLines(contracts.JobTypeBot, request, stream)
+}
+
+// GetBuildLog returns a stored build log or nil if it doesn't exist
+func (s *InMemoryServer) GetBuildLog(repoSource, repoOwner, repoName, buildID string) *BuildLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buildLogs[getInMemoryJobKey(contracts.JobTypeBuild, repoSource, repoOwner, repoName, buildID)]
+}
+
+// GetReleaseLog returns a stored release log or nil if it doesn't exist
+func (s *InMemoryServer) GetReleaseLog(repoSource, repoOwner, repoName, releaseID string) *ReleaseLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.releaseLogs[getInMemoryJobKey(contracts.JobTypeRelease, repoSource, repoOwner, repoName, releaseID)]
+}
+
+// GetBotLog returns a stored bot log or nil if it doesn't exist
+func (s *InMemoryServer) GetBotLog(repoSource, repoOwner, repoName, botID string) *BotLog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.botLogs[getInMemoryJobKey(contracts.JobTypeBot, repoSource, repoOwner, repoName, botID)]
+}
+
+func (s *InMemoryServer) pushLogLines(jobType contracts.JobType, stream grpc.ClientStreamingServer[JobTailLogLine, PushLogLinesResponse]) error {
+
+	var job *inMemoryJob
+	var jobKey string
+	var receivedLines int64
+
+	// ending the stream, also on an error, marks the job as finished so tailing clients stop waiting for more lines
+	finish := func() {
+		if job != nil {
+			s.mu.Lock()
+			s.finishJob(jobKey, job)
+			s.mu.Unlock()
+			job = nil
+		}
+	}
+	defer finish()
+
+	for {
+		line, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if line.GetJobId() == "" {
+			return status.Error(codes.InvalidArgument, "job_id needs to be set")
+		}
+		if line.GetTailLogLine() == nil {
+			return status.Error(codes.InvalidArgument, "tail_log_line needs to be set")
+		}
+
+		// all lines of a stream belong to the job of the first line
+		key := getInMemoryJobKey(jobType, line.GetRepoSource(), line.GetRepoOwner(), line.GetRepoName(), line.GetJobId())
+		if job != nil && key != jobKey {
+			return status.Errorf(codes.InvalidArgument, "job %v doesn't match job %v of earlier lines in the stream", key, jobKey)
+		}
+
+		s.mu.Lock()
+		if job == nil {
+			job = s.getOrCreateJob(key)
+			jobKey = key
+		}
+		job.lines = append(job.lines, line.GetTailLogLine())
+		job.notify()
+		s.mu.Unlock()
+
+		receivedLines++
+	}
+
+	// finish before responding, so the job is finished once the client receives the response
+	finish()
+
+	return stream.SendAndClose(&PushLogLinesResponse{ReceivedLines: receivedLines})
+}
+
+func (s *InMemoryServer) tailLogLines(jobType contracts.JobType, request *TailJobLogRequest, stream grpc.ServerStreamingServer[TailLogLine]) error {
+	if request.GetJobId() == "" {
+		return status.Error(codes.InvalidArgument, "job_id needs to be set")
+	}
+
+	key := getInMemoryJobKey(jobType, request.GetRepoSource(), request.GetRepoOwner(), request.GetRepoName(), request.GetJobId())
+
+	// clients can start tailing before the builder starts pushing; they wait for the job without adding it, so tailing unknown jobs leaves no state behind
+	var job *inMemoryJob
+	sent := 0
+	for {
+		var lines []*TailLogLine
+		finished := false
+
+		s.mu.Lock()
+		if job == nil {
+			job = s.jobs[key]
+		}
+		updated := s.jobsUpdated
+		if job != nil {
+			lines = job.lines[sent:]
+			finished = job.finished
+			updated = job.updated
+		}
+		s.mu.Unlock()
+
+		for _, l := range lines {
+			if err := stream.Send(l); err != nil {
+				return err
+			}
+			sent++
+		}
+
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-updated:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
+
+// getOrCreateJob returns the job for the key, creating it and waking up clients waiting for it if it doesn't exist yet; the caller needs to hold the lock
+func (s *InMemoryServer) getOrCreateJob(key string) *inMemoryJob {
+	job, ok := s.jobs[key]
+	if !ok {
+		job = &inMemoryJob{
+			updated: make(chan struct{}),
+		}
+		s.jobs[key] = job
+
+		close(s.jobsUpdated)
+		s.jobsUpdated = make(chan struct{})
+	}
+
+	return job
+}
+
+// finishJob marks the job as finished and evicts the oldest finished jobs beyond maxFinishedJobs; clients still tailing an evicted job keep
+// receiving its lines. The caller needs to hold the lock
+func (s *InMemoryServer) finishJob(key string, job *inMemoryJob) {
+	if !job.finished {
+		job.finished = true
+		s.finishedJobs = append(s.finishedJobs, key)
+	}
+	job.notify()
+
+	for len(s.finishedJobs) > s.maxFinishedJobs {
+		delete(s.jobs, s.finishedJobs[0])
+		s.finishedJobs = s.finishedJobs[1:]
+	}
+}
+
+// notify wakes up all tailing clients; the caller needs to hold the lock
+func (job *inMemoryJob) notify() {
+	close(job.updated)
+	job.updated = make(chan struct{})
+}
+
+func getInMemoryJobKey(jobType contracts.JobType, repoSource, repoOwner, repoName, jobID string) string {
+	return fmt.Sprintf("%v/%v/%v/%v/%v", jobType, repoSource, repoOwner, repoName, jobID)
+}
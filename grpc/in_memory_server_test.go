@@ -0,0 +1,282 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestInMemoryServer(t *testing.T) {
+	t.Run("StoresBuildLogsCreatedByClient", func(t *testing.T) {
+
+		server, client := startInMemoryServer(t)
+
+		// act
+		_, err := client.CreatePipelineBuildLogs(context.Background(), BuildLogToProto(getBuildLog()))
+
+		assert.Nil(t, err)
+		buildLog := server.GetBuildLog("github.com", "ziplineeci", "ziplinee-ci-api", "15")
+		if assert.NotNil(t, buildLog) {
+			assert.Equal(t, getBuildLog(), BuildLogFromProto(buildLog))
+		}
+	})
+
+	t.Run("StoresReleaseAndBotLogs", func(t *testing.T) {
+
+		server, client := startInMemoryServer(t)
+
+		// act
+		_, errRelease := client.CreatePipelineReleaseLogs(context.Background(), ReleaseLogToProto(&contracts.ReleaseLog{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", ReleaseID: "3"}))
+		_, errBot := client.CreatePipelineBotLogs(context.Background(), BotLogToProto(&contracts.BotLog{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", BotID: "4"}))
+
+		assert.Nil(t, errRelease)
+		assert.Nil(t, errBot)
+		assert.NotNil(t, server.GetReleaseLog("github.com", "ziplineeci", "ziplinee-ci-api", "3"))
+		assert.NotNil(t, server.GetBotLog("github.com", "ziplineeci", "ziplinee-ci-api", "4"))
+		assert.Nil(t, server.GetBotLog("github.com", "ziplineeci", "ziplinee-ci-api", "3"))
+	})
+
+	t.Run("ReturnsInvalidArgumentWithoutJobID", func(t *testing.T) {
+
+		_, client := startInMemoryServer(t)
+
+		// act
+		_, err := client.CreatePipelineBuildLogs(context.Background(), &BuildLog{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("TailsLinesPushedBeforeAndAfterTailingStarts", func(t *testing.T) {
+
+		_, client := startInMemoryServer(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		push, err := client.PushBuildLogLines(ctx)
+		assert.Nil(t, err)
+		err = push.Send(getJobTailLogLine("15", "line 1"))
+		assert.Nil(t, err)
+
+		tail, err := client.TailBuildLogLines(ctx, &TailJobLogRequest{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", JobId: "15"})
+		assert.Nil(t, err)
+
+		first, err := tail.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, "line 1", first.GetLogLine().GetText())
+
+		err = push.Send(getJobTailLogLine("15", "line 2"))
+		assert.Nil(t, err)
+
+		// act
+		response, err := push.CloseAndRecv()
+
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), response.GetReceivedLines())
+
+		second, err := tail.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, "line 2", second.GetLogLine().GetText())
+		_, err = tail.Recv()
+		assert.True(t, errors.Is(err, io.EOF))
+	})
+
+	t.Run("ReturnsInvalidArgumentIfJobChangesMidStream", func(t *testing.T) {
+
+		_, client := startInMemoryServer(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		push, err := client.PushBuildLogLines(ctx)
+		assert.Nil(t, err)
+		err = push.Send(getJobTailLogLine("15", "line 1"))
+		assert.Nil(t, err)
+		otherRepoLine := getJobTailLogLine("15", "line 2")
+		otherRepoLine.RepoName = "ziplinee-ci-web"
+		err = push.Send(otherRepoLine)
+		assert.Nil(t, err)
+
+		// act
+		_, err = push.CloseAndRecv()
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		tail, err := client.TailBuildLogLines(ctx, &TailJobLogRequest{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", JobId: "15"})
+		assert.Nil(t, err)
+		line, err := tail.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, "line 1", line.GetLogLine().GetText())
+		_, err = tail.Recv()
+		assert.True(t, errors.Is(err, io.EOF))
+	})
+
+	t.Run("ReturnsInvalidArgumentWithoutTailLogLine", func(t *testing.T) {
+
+		_, client := startInMemoryServer(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		push, err := client.PushBuildLogLines(ctx)
+		assert.Nil(t, err)
+		line := getJobTailLogLine("15", "line 1")
+		line.TailLogLine = nil
+		err = push.Send(line)
+		assert.Nil(t, err)
+
+		// act
+		_, err = push.CloseAndRecv()
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("KeepsJobTypesApart", func(t *testing.T) {
+
+		_, client := startInMemoryServer(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		push, err := client.PushReleaseLogLines(ctx)
+		assert.Nil(t, err)
+		err = push.Send(getJobTailLogLine("15", "release line"))
+		assert.Nil(t, err)
+		_, err = push.CloseAndRecv()
+		assert.Nil(t, err)
+
+		pushBot, err := client.PushBotLogLines(ctx)
+		assert.Nil(t, err)
+		_, err = pushBot.CloseAndRecv()
+		assert.Nil(t, err)
+
+		// act
+		tail, err := client.TailReleaseLogLines(ctx, &TailJobLogRequest{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", JobId: "15"})
+
+		assert.Nil(t, err)
+		line, err := tail.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, "release line", line.GetLogLine().GetText())
+		_, err = tail.Recv()
+		assert.True(t, errors.Is(err, io.EOF))
+
+		// a build with the same id only receives its own lines
+		buildTail, err := client.TailBuildLogLines(ctx, &TailJobLogRequest{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", JobId: "15"})
+		assert.Nil(t, err)
+		pushBuild, err := client.PushBuildLogLines(ctx)
+		assert.Nil(t, err)
+		err = pushBuild.Send(getJobTailLogLine("15", "build line"))
+		assert.Nil(t, err)
+		_, err = pushBuild.CloseAndRecv()
+		assert.Nil(t, err)
+		buildLine, err := buildTail.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, "build line", buildLine.GetLogLine().GetText())
+		_, err = buildTail.Recv()
+		assert.True(t, errors.Is(err, io.EOF))
+	})
+
+	t.Run("EvictsOldestFinishedJobs", func(t *testing.T) {
+
+		server, client := startInMemoryServer(t)
+		server.maxFinishedJobs = 1
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// act
+		for _, jobID := range []string{"15", "16"} {
+			push, err := client.PushBuildLogLines(ctx)
+			assert.Nil(t, err)
+			err = push.Send(getJobTailLogLine(jobID, "line 1"))
+			assert.Nil(t, err)
+			_, err = push.CloseAndRecv()
+			assert.Nil(t, err)
+		}
+
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		assert.Equal(t, 1, len(server.jobs))
+		assert.NotNil(t, server.jobs[getInMemoryJobKey(contracts.JobTypeBuild, "github.com", "ziplineeci", "ziplinee-ci-api", "16")])
+	})
+
+	t.Run("DoesNotAddJobWhenTailingUnknownJob", func(t *testing.T) {
+
+		server := NewInMemoryServer()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// act
+		err := server.TailBuildLogLines(&TailJobLogRequest{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", JobId: "15"}, &canceledTailStream{ctx: ctx})
+
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.Equal(t, 0, len(server.jobs))
+	})
+}
+
+// canceledTailStream is a server stream for calling tail methods directly, without a connection
+type canceledTailStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *canceledTailStream) Context() context.Context {
+	return stream.ctx
+}
+
+func (stream *canceledTailStream) Send(*TailLogLine) error {
+	return nil
+}
+
+func startInMemoryServer(t *testing.T) (*InMemoryServer, ZiplineeCiApiClient) {
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewInMemoryServer()
+
+	grpcServer := grpc.NewServer()
+	RegisterZiplineeCiApiServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return server, NewZiplineeCiApiClient(conn)
+}
+
+func getJobTailLogLine(jobID, text string) *JobTailLogLine {
+	return &JobTailLogLine{
+		RepoSource: "github.com",
+		RepoOwner:  "ziplineeci",
+		RepoName:   "ziplinee-ci-api",
+		JobId:      jobID,
+		TailLogLine: TailLogLineToProto(&contracts.TailLogLine{
+			Step: "build",
+			Type: contracts.LogTypeStage,
+			LogLine: &contracts.BuildLogLine{
+				LineNumber: 1,
+				Timestamp:  time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC),
+				StreamType: "stdout",
+				Text:       text,
+			},
+		}),
+	}
+}
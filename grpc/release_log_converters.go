@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+)
+
+// ReleaseLogToProto converts a release log contract into its protobuf message
+func ReleaseLogToProto(releaseLog *contracts.ReleaseLog) *ReleaseLog {
+	if releaseLog == nil {
+		return nil
+	}
+
+	return &ReleaseLog{
+		Id:         releaseLog.ID,
+		RepoSource: releaseLog.RepoSource,
+		RepoOwner:  releaseLog.RepoOwner,
+		RepoName:   releaseLog.RepoName,
+		ReleaseId:  releaseLog.ReleaseID,
+		Steps:      BuildLogStepsToProto(releaseLog.Steps),
+		InsertedAt: timeToProto(releaseLog.InsertedAt),
+	}
+}
+
+// ReleaseLogFromProto converts a protobuf message into a release log contract
+func ReleaseLogFromProto(releaseLog *ReleaseLog) *contracts.ReleaseLog {
+	if releaseLog == nil {
+		return nil
+	}
+
+	return &contracts.ReleaseLog{
+		ID:         releaseLog.GetId(),
+		RepoSource: releaseLog.GetRepoSource(),
+		RepoOwner:  releaseLog.GetRepoOwner(),
+		RepoName:   releaseLog.GetRepoName(),
+		ReleaseID:  releaseLog.GetReleaseId(),
+		Steps:      nonNilSteps(BuildLogStepsFromProto(releaseLog.GetSteps())),
+		InsertedAt: timeFromProto(releaseLog.GetInsertedAt()),
+	}
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// TailLogLineToProto converts a tail log line contract into its protobuf message
+func TailLogLineToProto(tailLogLine *contracts.TailLogLine) *TailLogLine {
+	if tailLogLine == nil {
+		return nil
+	}
+
+	protoTailLogLine := &TailLogLine{
+		Step:        tailLogLine.Step,
+		ParentStage: tailLogLine.ParentStage,
+		Type:        string(tailLogLine.Type),
+		Depth:       int32(tailLogLine.Depth),
+		RunIndex:    int32(tailLogLine.RunIndex),
+		Image:       BuildLogStepDockerImageToProto(tailLogLine.Image),
+	}
+	if tailLogLine.LogLine != nil {
+		protoTailLogLine.LogLine = BuildLogLineToProto(*tailLogLine.LogLine)
+	}
+	if tailLogLine.Duration != nil {
+		protoTailLogLine.Duration = durationpb.New(*tailLogLine.Duration)
+	}
+	if tailLogLine.ExitCode != nil {
+		exitCode := *tailLogLine.ExitCode
+		protoTailLogLine.ExitCode = &exitCode
+	}
+	if tailLogLine.Status != nil {
+		status := string(*tailLogLine.Status)
+		protoTailLogLine.Status = &status
+	}
+	if tailLogLine.AutoInjected != nil {
+		autoInjected := *tailLogLine.AutoInjected
+		protoTailLogLine.AutoInjected = &autoInjected
+	}
+
+	return protoTailLogLine
+}
+
+// TailLogLineFromProto converts a protobuf message into a tail log line contract
+func TailLogLineFromProto(tailLogLine *TailLogLine) *contracts.TailLogLine {
+	if tailLogLine == nil {
+		return nil
+	}
+
+	contractTailLogLine := &contracts.TailLogLine{
+		Step:        tailLogLine.GetStep(),
+		ParentStage: tailLogLine.GetParentStage(),
+		Type:        contracts.LogType(tailLogLine.GetType()),
+		Depth:       int(tailLogLine.GetDepth()),
+		RunIndex:    int(tailLogLine.GetRunIndex()),
+		Image:       BuildLogStepDockerImageFromProto(tailLogLine.GetImage()),
+	}
+	if tailLogLine.GetLogLine() != nil {
+		logLine := BuildLogLineFromProto(tailLogLine.GetLogLine())
+		contractTailLogLine.LogLine = &logLine
+	}
+	if tailLogLine.GetDuration() != nil {
+		duration := tailLogLine.GetDuration().AsDuration()
+		contractTailLogLine.Duration = &duration
+	}
+	if tailLogLine.ExitCode != nil {
+		exitCode := tailLogLine.GetExitCode()
+		contractTailLogLine.ExitCode = &exitCode
+	}
+	if tailLogLine.Status != nil {
+		status := contracts.LogStatus(tailLogLine.GetStatus())
+		contractTailLogLine.Status = &status
+	}
+	if tailLogLine.AutoInjected != nil {
+		autoInjected := tailLogLine.GetAutoInjected()
+		contractTailLogLine.AutoInjected = &autoInjected
+	}
+
+	return contractTailLogLine
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	contracts "github.com/ziplineeci/ziplinee-ci-contracts"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTailLogLineConverters(t *testing.T) {
+	t.Run("RoundTripsTailLogLineWithLogLine", func(t *testing.T) {
+
+		tailLogLine := &contracts.TailLogLine{
+			Step:        "postgres",
+			ParentStage: "integration",
+			Type:        contracts.LogTypeService,
+			Depth:       1,
+			RunIndex:    2,
+			LogLine: &contracts.BuildLogLine{
+				LineNumber: 7,
+				Timestamp:  time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC),
+				StreamType: "stderr",
+				Text:       "database system is ready to accept connections",
+			},
+		}
+
+		// act
+		bytes, err := proto.Marshal(TailLogLineToProto(tailLogLine))
+		assert.Nil(t, err)
+		var protoTailLogLine TailLogLine
+		err = proto.Unmarshal(bytes, &protoTailLogLine)
+		assert.Nil(t, err)
+
+		assert.Equal(t, tailLogLine, TailLogLineFromProto(&protoTailLogLine))
+	})
+
+	t.Run("RoundTripsTailLogLineWithStatusUpdate", func(t *testing.T) {
+
+		duration := 3 * time.Second
+		exitCode := int64(0)
+		status := contracts.LogStatusSucceeded
+		autoInjected := false
+		tailLogLine := &contracts.TailLogLine{
+			Step:         "build",
+			Type:         contracts.LogTypeStage,
+			Image:        &contracts.BuildLogStepDockerImage{Name: "golang", Tag: "1.22"},
+			Duration:     &duration,
+			ExitCode:     &exitCode,
+			Status:       &status,
+			AutoInjected: &autoInjected,
+		}
+
+		// act
+		bytes, err := proto.Marshal(TailLogLineToProto(tailLogLine))
+		assert.Nil(t, err)
+		var protoTailLogLine TailLogLine
+		err = proto.Unmarshal(bytes, &protoTailLogLine)
+		assert.Nil(t, err)
+
+		assert.Equal(t, tailLogLine, TailLogLineFromProto(&protoTailLogLine))
+	})
+
+	t.Run("RoundTripsReleaseAndBotLogs", func(t *testing.T) {
+
+		buildLog := getBuildLog()
+		releaseLog := &contracts.ReleaseLog{ID: "1", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", ReleaseID: "2", Steps: buildLog.Steps, InsertedAt: buildLog.InsertedAt}
+		botLog := &contracts.BotLog{ID: "1", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", BotID: "3", Steps: buildLog.Steps, InsertedAt: buildLog.InsertedAt}
+
+		// act
+		roundTrippedReleaseLog := ReleaseLogFromProto(ReleaseLogToProto(releaseLog))
+		roundTrippedBotLog := BotLogFromProto(BotLogToProto(botLog))
+
+		assert.Equal(t, releaseLog, roundTrippedReleaseLog)
+		assert.Equal(t, botLog, roundTrippedBotLog)
+	})
+}
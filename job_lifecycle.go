@@ -0,0 +1,208 @@
+package contracts
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock provides the current time to the lifecycle methods, so they can be driven by a fixed time in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc turns a function into a Clock
+type ClockFunc func() time.Time
+
+// Now returns the result of calling the function
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock returns the current time in UTC
+var SystemClock Clock = ClockFunc(func() time.Time {
+	return time.Now().UTC()
+})
+
+// IsFinished returns true if the status is final: succeeded, failed or canceled
+func (s Status) IsFinished() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCanceled
+}
+
+// MarkStarted sets the build to running and sets the time it started and how long it was pending since it was inserted;
+// if the build already started only its status and update time are set
+func (build *Build) MarkStarted(clock Clock) {
+	now := clock.Now()
+
+	build.BuildStatus = StatusRunning
+	build.UpdatedAt = now
+	if build.StartedAt == nil {
+		build.StartedAt = &now
+		pendingDuration := getElapsedDuration(getTimeOrNil(build.InsertedAt), now)
+		build.PendingDuration = &pendingDuration
+	}
+}
+
+// MarkFinished sets the build to the finished status and sets its duration since it started, or since it was inserted
+// if it never started
+func (build *Build) MarkFinished(clock Clock, status Status) error {
+	if !status.IsFinished() {
+		return fmt.Errorf("status %v is not a finished status", status)
+	}
+
+	now := clock.Now()
+
+	build.BuildStatus = status
+	build.UpdatedAt = now
+	build.Duration = getRunDuration(getTimeOrNil(build.InsertedAt), build.StartedAt, now)
+
+	return nil
+}
+
+// MarkStarted sets the release to running and sets the time it started and how long it was pending since it was inserted;
+// if the release already started only its status and update time are set
+func (release *Release) MarkStarted(clock Clock) {
+	now := clock.Now()
+
+	release.ReleaseStatus = StatusRunning
+	release.UpdatedAt = &now
+	if release.StartedAt == nil {
+		release.StartedAt = &now
+		pendingDuration := getElapsedDuration(release.InsertedAt, now)
+		release.PendingDuration = &pendingDuration
+	}
+}
+
+// MarkFinished sets the release to the finished status and sets its duration since it started, or since it was inserted
+// if it never started
+func (release *Release) MarkFinished(clock Clock, status Status) error {
+	if !status.IsFinished() {
+		return fmt.Errorf("status %v is not a finished status", status)
+	}
+
+	now := clock.Now()
+
+	release.ReleaseStatus = status
+	release.UpdatedAt = &now
+	duration := getRunDuration(release.InsertedAt, release.StartedAt, now)
+	release.Duration = &duration
+
+	return nil
+}
+
+// MarkStarted sets the bot to running and sets the time it started and how long it was pending since it was inserted;
+// if the bot already started only its status and update time are set
+func (bot *Bot) MarkStarted(clock Clock) {
+	now := clock.Now()
+
+	bot.BotStatus = StatusRunning
+	bot.UpdatedAt = &now
+	if bot.StartedAt == nil {
+		bot.StartedAt = &now
+		pendingDuration := getElapsedDuration(bot.InsertedAt, now)
+		bot.PendingDuration = &pendingDuration
+	}
+}
+
+// MarkFinished sets the bot to the finished status and sets its duration since it started, or since it was inserted
+// if it never started
+func (bot *Bot) MarkFinished(clock Clock, status Status) error {
+	if !status.IsFinished() {
+		return fmt.Errorf("status %v is not a finished status", status)
+	}
+
+	now := clock.Now()
+
+	bot.BotStatus = status
+	bot.UpdatedAt = &now
+	duration := getRunDuration(bot.InsertedAt, bot.StartedAt, now)
+	bot.Duration = &duration
+
+	return nil
+}
+
+// ApplyStatus sets the status of the build, release or bot in the event and updates its timestamps and durations:
+// running marks the job as started, succeeded, failed and canceled mark it as finished and any other status only
+// updates the time it was last updated
+func (bc *ZiplineeCiBuilderEvent) ApplyStatus(status Status, clock Clock) error {
+	if err := bc.Validate(); err != nil {
+		return err
+	}
+
+	switch {
+	case status == StatusRunning:
+		bc.markStarted(clock)
+	case status.IsFinished():
+		return bc.markFinished(clock, status)
+	default:
+		bc.SetStatus(status)
+		bc.markUpdated(clock)
+	}
+
+	return nil
+}
+
+func (bc *ZiplineeCiBuilderEvent) markStarted(clock Clock) {
+	switch bc.JobType {
+	case JobTypeBuild:
+		bc.Build.MarkStarted(clock)
+	case JobTypeRelease:
+		bc.Release.MarkStarted(clock)
+	case JobTypeBot:
+		bc.Bot.MarkStarted(clock)
+	}
+}
+
+func (bc *ZiplineeCiBuilderEvent) markFinished(clock Clock, status Status) error {
+	switch bc.JobType {
+	case JobTypeBuild:
+		return bc.Build.MarkFinished(clock, status)
+	case JobTypeRelease:
+		return bc.Release.MarkFinished(clock, status)
+	case JobTypeBot:
+		return bc.Bot.MarkFinished(clock, status)
+	}
+
+	return fmt.Errorf("job type %v is not supported", bc.JobType)
+}
+
+func (bc *ZiplineeCiBuilderEvent) markUpdated(clock Clock) {
+	now := clock.Now()
+
+	switch bc.JobType {
+	case JobTypeBuild:
+		bc.Build.UpdatedAt = now
+	case JobTypeRelease:
+		bc.Release.UpdatedAt = &now
+	case JobTypeBot:
+		bc.Bot.UpdatedAt = &now
+	}
+}
+
+// getElapsedDuration returns the time between from and to, or 0 if from is unknown or later than to because of clock
+// skew between services
+func getElapsedDuration(from *time.Time, to time.Time) time.Duration {
+	if from == nil || to.Before(*from) {
+		return 0
+	}
+
+	return to.Sub(*from)
+}
+
+// getRunDuration returns the time between starting, or inserting if the job never started, and finishing a job
+func getRunDuration(insertedAt, startedAt *time.Time, finishedAt time.Time) time.Duration {
+	start := startedAt
+	if start == nil {
+		start = insertedAt
+	}
+
+	return getElapsedDuration(start, finishedAt)
+}
+
+// getTimeOrNil returns nil for the zero time, so non-pointer timestamps can be handled like pointer ones
+func getTimeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
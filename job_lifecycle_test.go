@@ -0,0 +1,180 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMarkStarted(t *testing.T) {
+	t.Run("SetsStartedAtAndPendingDuration", func(t *testing.T) {
+
+		build := &Build{BuildStatus: StatusPending, InsertedAt: getLifecycleTime(0)}
+
+		// act
+		build.MarkStarted(getLifecycleClock(45 * time.Second))
+
+		assert.Equal(t, StatusRunning, build.BuildStatus)
+		assert.Equal(t, getLifecycleTime(45*time.Second), *build.StartedAt)
+		assert.Equal(t, getLifecycleTime(45*time.Second), build.UpdatedAt)
+		assert.Equal(t, 45*time.Second, *build.PendingDuration)
+	})
+
+	t.Run("KeepsStartedAtIfAlreadyStarted", func(t *testing.T) {
+
+		build := &Build{InsertedAt: getLifecycleTime(0)}
+		build.MarkStarted(getLifecycleClock(45 * time.Second))
+
+		// act
+		build.MarkStarted(getLifecycleClock(60 * time.Second))
+
+		assert.Equal(t, getLifecycleTime(45*time.Second), *build.StartedAt)
+		assert.Equal(t, getLifecycleTime(60*time.Second), build.UpdatedAt)
+		assert.Equal(t, 45*time.Second, *build.PendingDuration)
+	})
+}
+
+func TestBuildMarkFinished(t *testing.T) {
+	t.Run("SetsDurationSinceStart", func(t *testing.T) {
+
+		build := &Build{InsertedAt: getLifecycleTime(0)}
+		build.MarkStarted(getLifecycleClock(45 * time.Second))
+
+		// act
+		err := build.MarkFinished(getLifecycleClock(5*time.Minute), StatusSucceeded)
+
+		assert.Nil(t, err)
+		assert.Equal(t, StatusSucceeded, build.BuildStatus)
+		assert.Equal(t, 4*time.Minute+15*time.Second, build.Duration)
+		assert.Equal(t, getLifecycleTime(5*time.Minute), build.UpdatedAt)
+	})
+
+	t.Run("SetsDurationSinceInsertIfNeverStarted", func(t *testing.T) {
+
+		build := &Build{InsertedAt: getLifecycleTime(0)}
+
+		// act
+		err := build.MarkFinished(getLifecycleClock(time.Minute), StatusCanceled)
+
+		assert.Nil(t, err)
+		assert.Nil(t, build.StartedAt)
+		assert.Equal(t, time.Minute, build.Duration)
+	})
+
+	t.Run("ReturnsErrorForUnfinishedStatus", func(t *testing.T) {
+
+		build := &Build{}
+
+		// act
+		err := build.MarkFinished(getLifecycleClock(time.Minute), StatusCanceling)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, StatusUnknown, build.BuildStatus)
+	})
+}
+
+func TestReleaseLifecycle(t *testing.T) {
+	t.Run("SetsPointerTimestampsAndDurations", func(t *testing.T) {
+
+		insertedAt := getLifecycleTime(0)
+		release := &Release{InsertedAt: &insertedAt}
+
+		// act
+		release.MarkStarted(getLifecycleClock(10 * time.Second))
+		err := release.MarkFinished(getLifecycleClock(2*time.Minute), StatusFailed)
+
+		assert.Nil(t, err)
+		assert.Equal(t, StatusFailed, release.ReleaseStatus)
+		assert.Equal(t, getLifecycleTime(10*time.Second), *release.StartedAt)
+		assert.Equal(t, getLifecycleTime(2*time.Minute), *release.UpdatedAt)
+		assert.Equal(t, 10*time.Second, *release.PendingDuration)
+		assert.Equal(t, 110*time.Second, *release.Duration)
+	})
+
+	t.Run("SetsZeroPendingDurationWithoutInsertedAt", func(t *testing.T) {
+
+		release := &Release{}
+
+		// act
+		release.MarkStarted(getLifecycleClock(10 * time.Second))
+
+		assert.Equal(t, time.Duration(0), *release.PendingDuration)
+	})
+}
+
+func TestBotLifecycle(t *testing.T) {
+	t.Run("SetsPointerTimestampsAndDurations", func(t *testing.T) {
+
+		insertedAt := getLifecycleTime(0)
+		bot := &Bot{InsertedAt: &insertedAt}
+
+		// act
+		bot.MarkStarted(getLifecycleClock(5 * time.Second))
+		err := bot.MarkFinished(getLifecycleClock(35*time.Second), StatusSucceeded)
+
+		assert.Nil(t, err)
+		assert.Equal(t, StatusSucceeded, bot.BotStatus)
+		assert.Equal(t, 5*time.Second, *bot.PendingDuration)
+		assert.Equal(t, 30*time.Second, *bot.Duration)
+	})
+}
+
+func TestCiBuilderEventApplyStatus(t *testing.T) {
+	t.Run("DrivesLifecycleOfJobForJobType", func(t *testing.T) {
+
+		insertedAt := getLifecycleTime(0)
+		ciBuilderEvent := getCiBuilderEvent()
+		ciBuilderEvent.JobType = JobTypeRelease
+		ciBuilderEvent.Release = &Release{InsertedAt: &insertedAt, ReleaseStatus: StatusPending}
+
+		// act
+		startErr := ciBuilderEvent.ApplyStatus(StatusRunning, getLifecycleClock(20*time.Second))
+		cancelingErr := ciBuilderEvent.ApplyStatus(StatusCanceling, getLifecycleClock(50*time.Second))
+		finishErr := ciBuilderEvent.ApplyStatus(StatusCanceled, getLifecycleClock(time.Minute))
+
+		assert.Nil(t, startErr)
+		assert.Nil(t, cancelingErr)
+		assert.Nil(t, finishErr)
+		assert.Equal(t, StatusCanceled, ciBuilderEvent.GetStatus())
+		assert.Equal(t, 20*time.Second, *ciBuilderEvent.Release.PendingDuration)
+		assert.Equal(t, 40*time.Second, *ciBuilderEvent.Release.Duration)
+		assert.Nil(t, ciBuilderEvent.Bot.StartedAt)
+	})
+
+	t.Run("UpdatesTimestampForOtherStatuses", func(t *testing.T) {
+
+		ciBuilderEvent := getCiBuilderEvent()
+		ciBuilderEvent.JobType = JobTypeBuild
+
+		// act
+		err := ciBuilderEvent.ApplyStatus(StatusCanceling, getLifecycleClock(time.Minute))
+
+		assert.Nil(t, err)
+		assert.Equal(t, StatusCanceling, ciBuilderEvent.Build.BuildStatus)
+		assert.Equal(t, getLifecycleTime(time.Minute), ciBuilderEvent.Build.UpdatedAt)
+		assert.Nil(t, ciBuilderEvent.Build.StartedAt)
+	})
+
+	t.Run("ReturnsErrorForInvalidEvent", func(t *testing.T) {
+
+		ciBuilderEvent := getCiBuilderEvent()
+		ciBuilderEvent.JobType = JobTypeBot
+		ciBuilderEvent.Bot = nil
+
+		// act
+		err := ciBuilderEvent.ApplyStatus(StatusRunning, getLifecycleClock(time.Minute))
+
+		assert.NotNil(t, err)
+	})
+}
+
+func getLifecycleTime(offset time.Duration) time.Time {
+	return time.Date(2018, 4, 17, 8, 0, 0, 0, time.UTC).Add(offset)
+}
+
+func getLifecycleClock(offset time.Duration) Clock {
+	return ClockFunc(func() time.Time {
+		return getLifecycleTime(offset)
+	})
+}
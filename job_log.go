@@ -0,0 +1,191 @@
+package contracts
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JobLog is implemented by BuildLog, ReleaseLog and BotLog so storage, exporters and analyzers can handle any of them
+type JobLog interface {
+	GetJobType() JobType
+	GetJobID() string
+	GetRepoRef() RepoRef
+	GetFullRepoPath() string
+	GetSteps() []*BuildLogStep
+	GetInsertedAt() time.Time
+	GetAggregatedStatus() LogStatus
+	HasUnknownStatus() bool
+	HasSucceededStatus() bool
+	HasFailedStatus() bool
+	HasCanceledStatus() bool
+}
+
+var (
+	_ JobLog = &BuildLog{}
+	_ JobLog = &ReleaseLog{}
+	_ JobLog = &BotLog{}
+)
+
+// GetJobType returns JobTypeBuild
+func (buildLog *BuildLog) GetJobType() JobType {
+	return JobTypeBuild
+}
+
+// GetJobID returns the build id
+func (buildLog *BuildLog) GetJobID() string {
+	return buildLog.BuildID
+}
+
+// GetFullRepoPath returns the full path of the build log repository with source, owner and name
+func (buildLog *BuildLog) GetFullRepoPath() string {
+	return buildLog.GetRepoRef().String()
+}
+
+// GetSteps returns the steps of the build log, or nil for a nil build log
+func (buildLog *BuildLog) GetSteps() []*BuildLogStep {
+	if buildLog == nil {
+		return nil
+	}
+
+	return buildLog.Steps
+}
+
+// GetInsertedAt returns the time the build log was stored
+func (buildLog *BuildLog) GetInsertedAt() time.Time {
+	return buildLog.InsertedAt
+}
+
+// GetJobType returns JobTypeRelease
+func (releaseLog *ReleaseLog) GetJobType() JobType {
+	return JobTypeRelease
+}
+
+// GetJobID returns the release id
+func (releaseLog *ReleaseLog) GetJobID() string {
+	return releaseLog.ReleaseID
+}
+
+// GetFullRepoPath returns the full path of the release log repository with source, owner and name
+func (releaseLog *ReleaseLog) GetFullRepoPath() string {
+	return releaseLog.GetRepoRef().String()
+}
+
+// GetSteps returns the steps of the release log, or nil for a nil release log
+func (releaseLog *ReleaseLog) GetSteps() []*BuildLogStep {
+	if releaseLog == nil {
+		return nil
+	}
+
+	return releaseLog.Steps
+}
+
+// GetInsertedAt returns the time the release log was stored
+func (releaseLog *ReleaseLog) GetInsertedAt() time.Time {
+	return releaseLog.InsertedAt
+}
+
+// GetJobType returns JobTypeBot
+func (botLog *BotLog) GetJobType() JobType {
+	return JobTypeBot
+}
+
+// GetJobID returns the bot id
+func (botLog *BotLog) GetJobID() string {
+	return botLog.BotID
+}
+
+// GetFullRepoPath returns the full path of the bot log repository with source, owner and name
+func (botLog *BotLog) GetFullRepoPath() string {
+	return botLog.GetRepoRef().String()
+}
+
+// GetSteps returns the steps of the bot log, or nil for a nil bot log
+func (botLog *BotLog) GetSteps() []*BuildLogStep {
+	if botLog == nil {
+		return nil
+	}
+
+	return botLog.Steps
+}
+
+// GetInsertedAt returns the time the bot log was stored
+func (botLog *BotLog) GetInsertedAt() time.Time {
+	return botLog.InsertedAt
+}
+
+// JobLogEnvelope wraps a build, release or bot log together with its job type, so a mix of them can be stored and decoded again
+type JobLogEnvelope struct {
+	JobType JobType
+	Log     JobLog
+}
+
+type jobLogEnvelopeJSON struct {
+	JobType JobType         `json:"jobType"`
+	Log     json.RawMessage `json:"log"`
+}
+
+// NewJobLogEnvelope returns an envelope for the log with the job type set from the log
+func NewJobLogEnvelope(log JobLog) JobLogEnvelope {
+	return JobLogEnvelope{
+		JobType: log.GetJobType(),
+		Log:     log,
+	}
+}
+
+// MarshalJSON writes the envelope as {"jobType":...,"log":{...}}
+func (envelope JobLogEnvelope) MarshalJSON() ([]byte, error) {
+	if envelope.Log == nil {
+		return nil, errors.New("log needs to be set to marshal a job log envelope")
+	}
+
+	jobType := envelope.JobType
+	if jobType == JobTypeUnknown {
+		jobType = envelope.Log.GetJobType()
+	}
+	if jobType != envelope.Log.GetJobType() {
+		return nil, fmt.Errorf("job type %v of envelope doesn't match job type %v of log", jobType, envelope.Log.GetJobType())
+	}
+
+	log, err := json.Marshal(envelope.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jobLogEnvelopeJSON{
+		JobType: jobType,
+		Log:     log,
+	})
+}
+
+// UnmarshalJSON decodes the log into a BuildLog, ReleaseLog or BotLog depending on the job type
+func (envelope *JobLogEnvelope) UnmarshalJSON(data []byte) error {
+	var raw jobLogEnvelopeJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var log JobLog
+	switch raw.JobType {
+	case JobTypeBuild:
+		log = &BuildLog{}
+	case JobTypeRelease:
+		log = &ReleaseLog{}
+	case JobTypeBot:
+		log = &BotLog{}
+	default:
+		return fmt.Errorf("job type %q is not supported for job logs", raw.JobType)
+	}
+
+	if len(raw.Log) > 0 {
+		if err := json.Unmarshal(raw.Log, log); err != nil {
+			return err
+		}
+	}
+
+	envelope.JobType = raw.JobType
+	envelope.Log = log
+
+	return nil
+}
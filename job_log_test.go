@@ -0,0 +1,113 @@
+package contracts
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobLog(t *testing.T) {
+	t.Run("ExposesCommonFieldsForAllJobTypes", func(t *testing.T) {
+
+		steps := []*BuildLogStep{&BuildLogStep{Step: "deploy", Status: LogStatusCanceled}}
+		insertedAt := time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC)
+		jobLogs := []JobLog{
+			&BuildLog{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", BuildID: "1", Steps: steps, InsertedAt: insertedAt},
+			&ReleaseLog{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", ReleaseID: "2", Steps: steps, InsertedAt: insertedAt},
+			&BotLog{RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", BotID: "3", Steps: steps, InsertedAt: insertedAt},
+		}
+
+		for i, jobLog := range jobLogs {
+			// act
+			jobID := jobLog.GetJobID()
+
+			assert.Equal(t, []JobType{JobTypeBuild, JobTypeRelease, JobTypeBot}[i], jobLog.GetJobType())
+			assert.Equal(t, []string{"1", "2", "3"}[i], jobID)
+			assert.Equal(t, "github.com/ziplineeci/ziplinee-ci-api", jobLog.GetFullRepoPath())
+			assert.Equal(t, steps, jobLog.GetSteps())
+			assert.Equal(t, insertedAt, jobLog.GetInsertedAt())
+			assert.Equal(t, LogStatusCanceled, jobLog.GetAggregatedStatus())
+			assert.True(t, jobLog.HasCanceledStatus())
+			assert.False(t, jobLog.HasSucceededStatus())
+		}
+	})
+
+	t.Run("AnalyzesAnyJobType", func(t *testing.T) {
+
+		jobLogs := []JobLog{
+			&BuildLog{BuildID: "1", Steps: []*BuildLogStep{getTruncationStep("build", 10)}},
+			&ReleaseLog{ReleaseID: "2", Steps: []*BuildLogStep{getTruncationStep("deploy", 10)}},
+			&BotLog{BotID: "3", Steps: []*BuildLogStep{getTruncationStep("bot", 10)}},
+		}
+
+		for _, jobLog := range jobLogs {
+			// act
+			stats := TruncateJobLog(jobLog, LogTruncationPolicy{MaxLinesPerStep: 4, TailLines: 1})
+			hits, err := SearchJobLog(jobLog, LogSearchQuery{Text: "line 10"})
+
+			assert.True(t, stats.IsTruncated())
+			assert.Equal(t, 4, len(jobLog.GetSteps()[0].LogLines))
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(hits))
+		}
+	})
+}
+
+func TestJobLogEnvelope(t *testing.T) {
+	t.Run("RoundTripsEachJobTypeThroughJSON", func(t *testing.T) {
+
+		jobLogs := []JobLog{
+			&BuildLog{ID: "5", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", RepoBranch: "main", BuildID: "1", Steps: []*BuildLogStep{}},
+			&ReleaseLog{ID: "6", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", ReleaseID: "2", Steps: []*BuildLogStep{}},
+			&BotLog{ID: "7", RepoSource: "github.com", RepoOwner: "ziplineeci", RepoName: "ziplinee-ci-api", BotID: "3", Steps: []*BuildLogStep{}},
+		}
+
+		for _, jobLog := range jobLogs {
+			bytes, err := json.Marshal(NewJobLogEnvelope(jobLog))
+			assert.Nil(t, err)
+
+			// act
+			var envelope JobLogEnvelope
+			err = json.Unmarshal(bytes, &envelope)
+
+			assert.Nil(t, err)
+			assert.Equal(t, jobLog.GetJobType(), envelope.JobType)
+			assert.Equal(t, jobLog, envelope.Log)
+		}
+	})
+
+	t.Run("DecodesListOfMixedJobLogs", func(t *testing.T) {
+
+		data := `[{"jobType":"build","log":{"buildID":"1","repoBranch":"main"}},{"jobType":"bot","log":{"botID":"3"}}]`
+
+		// act
+		var envelopes []JobLogEnvelope
+		err := json.Unmarshal([]byte(data), &envelopes)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(envelopes))
+		buildLog, ok := envelopes[0].Log.(*BuildLog)
+		assert.True(t, ok)
+		assert.Equal(t, "main", buildLog.RepoBranch)
+		assert.Equal(t, "3", envelopes[1].Log.GetJobID())
+	})
+
+	t.Run("ReturnsErrorForUnknownJobType", func(t *testing.T) {
+
+		// act
+		var envelope JobLogEnvelope
+		err := json.Unmarshal([]byte(`{"jobType":"cron","log":{}}`), &envelope)
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ReturnsErrorForMismatchingJobType", func(t *testing.T) {
+
+		// act
+		_, err := json.Marshal(JobLogEnvelope{JobType: JobTypeRelease, Log: &BuildLog{}})
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,281 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	manifest "github.com/ziplineeci/ziplinee-ci-manifest"
+)
+
+// LabelSelectorOperator is the operator of a single requirement in a label selector
+type LabelSelectorOperator string
+
+const (
+	// LabelSelectorOperatorEquals matches if a label with the key has the value, like team=payments or team==payments
+	LabelSelectorOperatorEquals LabelSelectorOperator = "="
+	// LabelSelectorOperatorNotEquals matches if no label with the key has the value, like team!=payments
+	LabelSelectorOperatorNotEquals LabelSelectorOperator = "!="
+	// LabelSelectorOperatorIn matches if a label with the key has one of the values, like type in (api,web)
+	LabelSelectorOperatorIn LabelSelectorOperator = "in"
+	// LabelSelectorOperatorNotIn matches if no label with the key has one of the values, like type notin (api,web)
+	LabelSelectorOperatorNotIn LabelSelectorOperator = "notin"
+	// LabelSelectorOperatorExists matches if a label with the key exists, like deprecated
+	LabelSelectorOperatorExists LabelSelectorOperator = "exists"
+	// LabelSelectorOperatorDoesNotExist matches if no label with the key exists, like !deprecated
+	LabelSelectorOperatorDoesNotExist LabelSelectorOperator = "!"
+)
+
+// label selector values can hold anything labels can, except for characters separating requirements, operators and values
+var (
+	labelSelectorKeyRegex   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]*[A-Za-z0-9])?$`)
+	labelSelectorValueRegex = regexp.MustCompile(`^[^,()=!\s]*$`)
+	labelSelectorSetRegex   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Labelled is implemented by contracts carrying labels, so they can be matched by a LabelSelector
+type Labelled interface {
+	GetLabels() []Label
+}
+
+// GetLabels returns the labels of the build
+func (build *Build) GetLabels() []Label {
+	return build.Labels
+}
+
+// GetLabels returns the labels of the pipeline
+func (pipeline *Pipeline) GetLabels() []Label {
+	return pipeline.Labels
+}
+
+// GetLabels returns the labels of the catalog entity
+func (catalogEntity *CatalogEntity) GetLabels() []Label {
+	return catalogEntity.Labels
+}
+
+// LabelRequirement is a single requirement in a label selector
+type LabelRequirement struct {
+	Key      string                `json:"key"`
+	Operator LabelSelectorOperator `json:"operator"`
+	Values   []string              `json:"values,omitempty"`
+}
+
+// LabelSelector selects labelled contracts for which all requirements match; an empty selector matches everything
+type LabelSelector struct {
+	Requirements []LabelRequirement `json:"requirements"`
+}
+
+// ParseLabelSelector parses a comma separated list of requirements, like team=payments,type in (api,web),!deprecated
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+
+	labelSelector := LabelSelector{
+		Requirements: []LabelRequirement{},
+	}
+
+	if strings.TrimSpace(selector) == "" {
+		return labelSelector, nil
+	}
+
+	parts, err := splitLabelSelector(selector)
+	if err != nil {
+		return labelSelector, err
+	}
+
+	for _, p := range parts {
+		requirement, err := parseLabelRequirement(strings.TrimSpace(p))
+		if err != nil {
+			return labelSelector, err
+		}
+		labelSelector.Requirements = append(labelSelector.Requirements, requirement)
+	}
+
+	return labelSelector, nil
+}
+
+// Matches returns true if the labels of the labelled contract match all requirements
+func (selector LabelSelector) Matches(labelled Labelled) bool {
+	return selector.MatchesLabels(labelled.GetLabels())
+}
+
+// MatchesLabels returns true if the labels match all requirements
+func (selector LabelSelector) MatchesLabels(labels []Label) bool {
+	for _, r := range selector.Requirements {
+		if !r.Matches(labels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate returns an error if a value in the selector can never match because it doesn't match the regex for its key in the manifest preferences;
+// values of != and notin requirements aren't checked, since a value that can never match just makes those requirements always hold
+func (selector LabelSelector) Validate(preferences *manifest.ZiplineeManifestPreferences) error {
+	if preferences == nil {
+		return nil
+	}
+
+	for _, r := range selector.Requirements {
+		if r.Operator == LabelSelectorOperatorNotEquals || r.Operator == LabelSelectorOperatorNotIn {
+			continue
+		}
+		labelRegex, ok := preferences.LabelRegexes[r.Key]
+		if !ok || labelRegex == "" {
+			continue
+		}
+
+		pattern, err := regexp.Compile(fmt.Sprintf("^(%v)$", strings.TrimSpace(labelRegex)))
+		if err != nil {
+			return fmt.Errorf("label regex for key %v is invalid: %w", r.Key, err)
+		}
+		for _, v := range r.Values {
+			if !pattern.MatchString(v) {
+				return fmt.Errorf("value %v for label %v doesn't match regex %v", v, r.Key, labelRegex)
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns the selector in its canonical form
+func (selector LabelSelector) String() string {
+	requirements := make([]string, 0, len(selector.Requirements))
+	for _, r := range selector.Requirements {
+		requirements = append(requirements, r.String())
+	}
+
+	return strings.Join(requirements, ",")
+}
+
+// Matches returns true if the labels meet the requirement
+func (requirement LabelRequirement) Matches(labels []Label) bool {
+
+	hasKey := false
+	hasValue := false
+	for _, l := range labels {
+		if l.Key != requirement.Key {
+			continue
+		}
+		hasKey = true
+		for _, v := range requirement.Values {
+			if l.Value == v {
+				hasValue = true
+			}
+		}
+	}
+
+	switch requirement.Operator {
+	case LabelSelectorOperatorEquals, LabelSelectorOperatorIn:
+		return hasValue
+	case LabelSelectorOperatorNotEquals, LabelSelectorOperatorNotIn:
+		return !hasValue
+	case LabelSelectorOperatorExists:
+		return hasKey
+	case LabelSelectorOperatorDoesNotExist:
+		return !hasKey
+	}
+
+	return false
+}
+
+// String returns the requirement in its canonical form
+func (requirement LabelRequirement) String() string {
+	switch requirement.Operator {
+	case LabelSelectorOperatorIn, LabelSelectorOperatorNotIn:
+		return fmt.Sprintf("%v %v (%v)", requirement.Key, requirement.Operator, strings.Join(requirement.Values, ","))
+	case LabelSelectorOperatorExists:
+		return requirement.Key
+	case LabelSelectorOperatorDoesNotExist:
+		return "!" + requirement.Key
+	}
+
+	return fmt.Sprintf("%v%v%v", requirement.Key, requirement.Operator, strings.Join(requirement.Values, ","))
+}
+
+// splitLabelSelector splits the selector on commas that aren't inside parentheses
+func splitLabelSelector(selector string) ([]string, error) {
+
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("label selector %q has nested parentheses", selector)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("label selector %q has an unexpected closing parenthesis", selector)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("label selector %q has an unclosed parenthesis", selector)
+	}
+
+	return append(parts, selector[start:]), nil
+}
+
+func parseLabelRequirement(requirement string) (LabelRequirement, error) {
+
+	if requirement == "" {
+		return LabelRequirement{}, errors.New("label selector has an empty requirement")
+	}
+
+	if matches := labelSelectorSetRegex.FindStringSubmatch(requirement); matches != nil {
+		values := []string{}
+		for _, v := range strings.Split(matches[3], ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				return LabelRequirement{}, fmt.Errorf("requirement %q has an empty value", requirement)
+			}
+			values = append(values, v)
+		}
+		return newLabelRequirement(requirement, matches[1], LabelSelectorOperator(matches[2]), values)
+	}
+
+	if strings.HasPrefix(requirement, "!") && !strings.Contains(requirement, "=") {
+		return newLabelRequirement(requirement, strings.TrimSpace(requirement[1:]), LabelSelectorOperatorDoesNotExist, nil)
+	}
+
+	for _, operator := range []string{"!=", "==", "="} {
+		if i := strings.Index(requirement, operator); i >= 0 {
+			key := strings.TrimSpace(requirement[:i])
+			value := strings.TrimSpace(requirement[i+len(operator):])
+			if operator == "!=" {
+				return newLabelRequirement(requirement, key, LabelSelectorOperatorNotEquals, []string{value})
+			}
+			return newLabelRequirement(requirement, key, LabelSelectorOperatorEquals, []string{value})
+		}
+	}
+
+	return newLabelRequirement(requirement, requirement, LabelSelectorOperatorExists, nil)
+}
+
+func newLabelRequirement(requirement, key string, operator LabelSelectorOperator, values []string) (LabelRequirement, error) {
+
+	if !labelSelectorKeyRegex.MatchString(key) {
+		return LabelRequirement{}, fmt.Errorf("requirement %q has an invalid key %q", requirement, key)
+	}
+	for _, v := range values {
+		if !labelSelectorValueRegex.MatchString(v) {
+			return LabelRequirement{}, fmt.Errorf("requirement %q has an invalid value %q", requirement, v)
+		}
+	}
+
+	return LabelRequirement{
+		Key:      key,
+		Operator: operator,
+		Values:   values,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	manifest "github.com/ziplineeci/ziplinee-ci-manifest"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	t.Run("ParsesAllOperators", func(t *testing.T) {
+
+		// act
+		selector, err := ParseLabelSelector("team=payments, tier==backend,env!=dev,type in (api, web),language notin (java),app,!deprecated")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []LabelRequirement{
+			{Key: "team", Operator: LabelSelectorOperatorEquals, Values: []string{"payments"}},
+			{Key: "tier", Operator: LabelSelectorOperatorEquals, Values: []string{"backend"}},
+			{Key: "env", Operator: LabelSelectorOperatorNotEquals, Values: []string{"dev"}},
+			{Key: "type", Operator: LabelSelectorOperatorIn, Values: []string{"api", "web"}},
+			{Key: "language", Operator: LabelSelectorOperatorNotIn, Values: []string{"java"}},
+			{Key: "app", Operator: LabelSelectorOperatorExists},
+			{Key: "deprecated", Operator: LabelSelectorOperatorDoesNotExist},
+		}, selector.Requirements)
+		assert.Equal(t, "team=payments,tier=backend,env!=dev,type in (api,web),language notin (java),app,!deprecated", selector.String())
+	})
+
+	t.Run("AcceptsValuesWithOtherCharacters", func(t *testing.T) {
+
+		// act
+		selector, err := ParseLabelSelector("version=v1.0+build.7,owner in (team:payments,jane@example.com)")
+
+		assert.Nil(t, err)
+		if assert.Equal(t, 2, len(selector.Requirements)) {
+			assert.Equal(t, []string{"v1.0+build.7"}, selector.Requirements[0].Values)
+			assert.Equal(t, []string{"team:payments", "jane@example.com"}, selector.Requirements[1].Values)
+		}
+	})
+
+	t.Run("ReturnsEmptySelectorForEmptyString", func(t *testing.T) {
+
+		// act
+		selector, err := ParseLabelSelector(" ")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(selector.Requirements))
+		assert.True(t, selector.MatchesLabels(nil))
+	})
+
+	t.Run("ReturnsErrorForInvalidSelectors", func(t *testing.T) {
+
+		for _, selector := range []string{
+			"team=payments,",
+			"=payments",
+			"type in (api,web",
+			"type in (api,,web)",
+			"type in ((api))",
+			"team=pay ments",
+			"team=pay!ments",
+			"!",
+		} {
+			// act
+			_, err := ParseLabelSelector(selector)
+
+			assert.NotNil(t, err, selector)
+		}
+	})
+}
+
+func TestLabelSelectorMatches(t *testing.T) {
+	t.Run("MatchesLabelledContracts", func(t *testing.T) {
+
+		selector, err := ParseLabelSelector("team=payments,type in (api,web),!deprecated")
+		assert.Nil(t, err)
+
+		// act
+		build := selector.Matches(&Build{Labels: []Label{{Key: "team", Value: "payments"}, {Key: "type", Value: "api"}}})
+		pipeline := selector.Matches(&Pipeline{Labels: []Label{{Key: "team", Value: "payments"}, {Key: "type", Value: "worker"}}})
+		catalogEntity := selector.Matches(&CatalogEntity{Labels: []Label{{Key: "team", Value: "payments"}, {Key: "type", Value: "web"}, {Key: "deprecated", Value: "true"}}})
+
+		assert.True(t, build)
+		assert.False(t, pipeline)
+		assert.False(t, catalogEntity)
+	})
+
+	t.Run("NegativeOperatorsMatchMissingKeys", func(t *testing.T) {
+
+		selector, err := ParseLabelSelector("team!=payments,type notin (api)")
+		assert.Nil(t, err)
+
+		// act
+		matches := selector.MatchesLabels([]Label{{Key: "app", Value: "ziplinee-ci-api"}})
+
+		assert.True(t, matches)
+	})
+}
+
+func TestLabelSelectorValidate(t *testing.T) {
+	t.Run("ReturnsErrorForValueNotMatchingLabelRegex", func(t *testing.T) {
+
+		preferences := &manifest.ZiplineeManifestPreferences{
+			LabelRegexes: map[string]string{
+				"type": "api|web|worker",
+			},
+		}
+		valid, _ := ParseLabelSelector("type in (api,web),team=payments")
+		invalid, _ := ParseLabelSelector("type in (api,cron)")
+
+		// act
+		validErr := valid.Validate(preferences)
+		invalidErr := invalid.Validate(preferences)
+
+		assert.Nil(t, validErr)
+		assert.NotNil(t, invalidErr)
+	})
+
+	t.Run("IgnoresValuesOfNegativeOperators", func(t *testing.T) {
+
+		preferences := &manifest.ZiplineeManifestPreferences{
+			LabelRegexes: map[string]string{
+				"type": "api|web|worker",
+			},
+		}
+		selector, _ := ParseLabelSelector("type notin (api,cron),type!=batch")
+
+		// act
+		err := selector.Validate(preferences)
+
+		assert.Nil(t, err)
+	})
+}
@@ -32,6 +32,7 @@ type Pipeline struct {
 	PendingDuration      *time.Duration             `json:"pendingDuration,omitempty"`
 	LastUpdatedAt        time.Time                  `json:"lastUpdatedAt"`
 	ManifestObject       *manifest.ZiplineeManifest `json:"-"`
+	ManifestWarnings     []Warning                  `json:"-"`
 	RecentCommitters     []string                   `json:"recentCommitters,omitempty"`
 	RecentReleasers      []string                   `json:"recentReleasers,omitempty"`
 	ExtraInfo            *PipelineExtraInfo         `json:"extraInfo,omitempty"`
@@ -46,6 +47,14 @@ func (pipeline *Pipeline) GetFullRepoPath() string {
 
 // PipelineExtraInfo contains extra information like aggregates over the last x builds
 type PipelineExtraInfo struct {
-	MedianPendingDuration time.Duration `json:"medianPendingDuration"`
-	MedianDuration        time.Duration `json:"medianDuration"`
+	MedianPendingDuration time.Duration   `json:"medianPendingDuration"`
+	MedianDuration        time.Duration   `json:"medianDuration"`
+	P90PendingDuration    time.Duration   `json:"p90PendingDuration,omitempty"`
+	P95PendingDuration    time.Duration   `json:"p95PendingDuration,omitempty"`
+	P90Duration           time.Duration   `json:"p90Duration,omitempty"`
+	P95Duration           time.Duration   `json:"p95Duration,omitempty"`
+	SuccessRate           float64         `json:"successRate"`
+	FailureRate           float64         `json:"failureRate"`
+	Count                 int             `json:"count,omitempty"`
+	Trend                 *ExtraInfoTrend `json:"trend,omitempty"`
 }
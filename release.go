@@ -30,8 +30,16 @@ type Release struct {
 
 // ReleaseExtraInfo contains extra information like aggregates over the last x releases
 type ReleaseExtraInfo struct {
-	MedianPendingDuration time.Duration `json:"medianPendingDuration"`
-	MedianDuration        time.Duration `json:"medianDuration"`
+	MedianPendingDuration time.Duration   `json:"medianPendingDuration"`
+	MedianDuration        time.Duration   `json:"medianDuration"`
+	P90PendingDuration    time.Duration   `json:"p90PendingDuration,omitempty"`
+	P95PendingDuration    time.Duration   `json:"p95PendingDuration,omitempty"`
+	P90Duration           time.Duration   `json:"p90Duration,omitempty"`
+	P95Duration           time.Duration   `json:"p95Duration,omitempty"`
+	SuccessRate           float64         `json:"successRate"`
+	FailureRate           float64         `json:"failureRate"`
+	Count                 int             `json:"count,omitempty"`
+	Trend                 *ExtraInfoTrend `json:"trend,omitempty"`
 }
 
 // GetFullRepoPath returns the full path of the release repository with source, owner and name
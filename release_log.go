@@ -35,5 +35,5 @@ func (releaseLog *ReleaseLog) HasFailedStatus() bool {
 
 // HasCanceledStatus returns true if aggregated status is canceled
 func (releaseLog *ReleaseLog) HasCanceledStatus() bool {
-	return HasSucceededStatus(releaseLog.Steps)
+	return HasCanceledStatus(releaseLog.Steps)
 }
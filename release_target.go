@@ -1,10 +1,117 @@
 package contracts
 
-import manifest "github.com/ziplineeci/ziplinee-ci-manifest"
+import (
+	"sort"
+
+	manifest "github.com/ziplineeci/ziplinee-ci-manifest"
+)
 
 // ReleaseTarget contains the information to visualize and trigger release
 type ReleaseTarget struct {
 	Name           string                           `json:"name"`
 	Actions        []manifest.ZiplineeReleaseAction `json:"actions,omitempty"`
 	ActiveReleases []Release                        `json:"activeReleases,omitempty"`
+	CurrentVersion string                           `json:"currentVersion,omitempty"`
+}
+
+// GetReleaseTargets returns a release target for every release in the manifest, in manifest order, with the latest
+// release per action as active releases and the version of the latest succeeded release as current version;
+// releases for targets no longer in the manifest are ignored
+func GetReleaseTargets(mft *manifest.ZiplineeManifest, releases []*Release) []ReleaseTarget {
+
+	releaseTargets := []ReleaseTarget{}
+	if mft == nil {
+		return releaseTargets
+	}
+
+	// latest first, so the first release found per target and action is the active one
+	sortedReleases := make([]*Release, 0, len(releases))
+	for _, r := range releases {
+		if r != nil {
+			sortedReleases = append(sortedReleases, r)
+		}
+	}
+	sort.SliceStable(sortedReleases, func(i, j int) bool {
+		return getTimeOrZero(sortedReleases[i].InsertedAt).After(getTimeOrZero(sortedReleases[j].InsertedAt))
+	})
+
+	for _, r := range mft.Releases {
+		if r == nil {
+			continue
+		}
+
+		releaseTarget := ReleaseTarget{
+			Name: r.Name,
+		}
+		for _, a := range r.Actions {
+			if a != nil {
+				releaseTarget.Actions = append(releaseTarget.Actions, *a)
+			}
+		}
+
+		seenActions := map[string]bool{}
+		for _, sr := range sortedReleases {
+			if sr.Name != r.Name {
+				continue
+			}
+			if !seenActions[sr.Action] {
+				seenActions[sr.Action] = true
+				releaseTarget.ActiveReleases = append(releaseTarget.ActiveReleases, *sr)
+			}
+			if releaseTarget.CurrentVersion == "" && sr.ReleaseStatus == StatusSucceeded {
+				releaseTarget.CurrentVersion = sr.ReleaseVersion
+			}
+		}
+
+		releaseTargets = append(releaseTargets, releaseTarget)
+	}
+
+	return releaseTargets
+}
+
+// IsBehind returns true if the current version of the release target is lower than the version of the latest
+// successful build, or differs from it if either version isn't a semantic version
+func (releaseTarget *ReleaseTarget) IsBehind(latestSuccessfulBuild *Build) bool {
+	if latestSuccessfulBuild == nil || latestSuccessfulBuild.BuildVersion == "" {
+		return false
+	}
+
+	currentVersion, currentErr := ParseSemanticVersion(releaseTarget.CurrentVersion)
+	buildVersion, buildErr := latestSuccessfulBuild.GetSemanticVersion()
+	if currentErr != nil || buildErr != nil {
+		return releaseTarget.CurrentVersion != latestSuccessfulBuild.BuildVersion
+	}
+
+	return currentVersion.Compare(buildVersion) < 0
+}
+
+// GetLatestSuccessfulBuild returns the most recently inserted succeeded build on one of the release branches, as set
+// in the semver version of the manifest, or nil if none of those builds succeeded; without release branches the
+// manifest defaults master and main are used
+func GetLatestSuccessfulBuild(builds []*Build, releaseBranch manifest.StringOrStringArray) *Build {
+
+	if len(releaseBranch.Values) == 0 {
+		releaseBranch.Values = []string{"master", "main"}
+	}
+
+	var latest *Build
+	for _, b := range builds {
+		if b == nil || b.BuildStatus != StatusSucceeded || !releaseBranch.Contains(b.RepoBranch) {
+			continue
+		}
+		if latest == nil || b.InsertedAt.After(latest.InsertedAt) {
+			latest = b
+		}
+	}
+
+	return latest
+}
+
+// GetReleaseBranch returns the release branches from the semver version of the manifest
+func GetReleaseBranch(mft *manifest.ZiplineeManifest) manifest.StringOrStringArray {
+	if mft == nil || mft.Version.SemVer == nil {
+		return manifest.StringOrStringArray{}
+	}
+
+	return mft.Version.SemVer.ReleaseBranch
 }
@@ -0,0 +1,140 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	manifest "github.com/ziplineeci/ziplinee-ci-manifest"
+)
+
+func TestGetReleaseTargets(t *testing.T) {
+	t.Run("ReturnsTargetPerManifestReleaseWithActions", func(t *testing.T) {
+
+		mft := getReleaseTargetsManifest()
+
+		// act
+		releaseTargets := GetReleaseTargets(mft, nil)
+
+		if assert.Equal(t, 2, len(releaseTargets)) {
+			assert.Equal(t, "development", releaseTargets[0].Name)
+			assert.Nil(t, releaseTargets[0].Actions)
+			assert.Equal(t, "production", releaseTargets[1].Name)
+			assert.Equal(t, []manifest.ZiplineeReleaseAction{{Name: "deploy-canary"}, {Name: "deploy-stable", HideBadge: true}}, releaseTargets[1].Actions)
+			assert.Nil(t, releaseTargets[1].ActiveReleases)
+			assert.Equal(t, "", releaseTargets[1].CurrentVersion)
+		}
+	})
+
+	t.Run("MergesLatestReleasePerTargetAndAction", func(t *testing.T) {
+
+		mft := getReleaseTargetsManifest()
+		releases := []*Release{
+			getReleaseTargetsRelease("production", "deploy-canary", "1.0.0", StatusSucceeded, 1),
+			getReleaseTargetsRelease("production", "deploy-stable", "1.0.0", StatusSucceeded, 2),
+			getReleaseTargetsRelease("production", "deploy-canary", "1.1.0", StatusFailed, 3),
+			getReleaseTargetsRelease("development", "", "1.1.0", StatusSucceeded, 4),
+			getReleaseTargetsRelease("development", "", "1.2.0", StatusRunning, 5),
+			getReleaseTargetsRelease("staging", "", "1.2.0", StatusSucceeded, 6),
+		}
+
+		// act
+		releaseTargets := GetReleaseTargets(mft, releases)
+
+		if assert.Equal(t, 2, len(releaseTargets)) {
+			development := releaseTargets[0]
+			if assert.Equal(t, 1, len(development.ActiveReleases)) {
+				assert.Equal(t, "1.2.0", development.ActiveReleases[0].ReleaseVersion)
+			}
+			assert.Equal(t, "1.1.0", development.CurrentVersion)
+
+			production := releaseTargets[1]
+			if assert.Equal(t, 2, len(production.ActiveReleases)) {
+				assert.Equal(t, "deploy-canary", production.ActiveReleases[0].Action)
+				assert.Equal(t, StatusFailed, production.ActiveReleases[0].ReleaseStatus)
+				assert.Equal(t, "deploy-stable", production.ActiveReleases[1].Action)
+			}
+			assert.Equal(t, "1.0.0", production.CurrentVersion)
+		}
+	})
+
+	t.Run("ReturnsEmptyTargetsWithoutManifest", func(t *testing.T) {
+
+		// act
+		releaseTargets := GetReleaseTargets(nil, nil)
+
+		assert.Equal(t, []ReleaseTarget{}, releaseTargets)
+	})
+}
+
+func TestReleaseTargetIsBehind(t *testing.T) {
+	t.Run("ReturnsTrueIfCurrentVersionIsLowerThanLatestSuccessfulBuild", func(t *testing.T) {
+
+		builds := []*Build{
+			&Build{BuildVersion: "1.1.0", RepoBranch: "master", BuildStatus: StatusSucceeded, InsertedAt: time.Date(2018, 4, 17, 8, 1, 0, 0, time.UTC)},
+			&Build{BuildVersion: "1.3.0", RepoBranch: "master", BuildStatus: StatusFailed, InsertedAt: time.Date(2018, 4, 17, 8, 3, 0, 0, time.UTC)},
+			&Build{BuildVersion: "1.2.0", RepoBranch: "master", BuildStatus: StatusSucceeded, InsertedAt: time.Date(2018, 4, 17, 8, 2, 0, 0, time.UTC)},
+			&Build{BuildVersion: "1.2.1-feature", RepoBranch: "feature", BuildStatus: StatusSucceeded, InsertedAt: time.Date(2018, 4, 17, 8, 4, 0, 0, time.UTC)},
+		}
+		latestSuccessfulBuild := GetLatestSuccessfulBuild(builds, GetReleaseBranch(getReleaseTargetsManifest()))
+
+		// act
+		behind := (&ReleaseTarget{CurrentVersion: "1.1.0"}).IsBehind(latestSuccessfulBuild)
+		upToDate := (&ReleaseTarget{CurrentVersion: "1.2.0"}).IsBehind(latestSuccessfulBuild)
+		ahead := (&ReleaseTarget{CurrentVersion: "1.10.0"}).IsBehind(latestSuccessfulBuild)
+		neverReleased := (&ReleaseTarget{}).IsBehind(latestSuccessfulBuild)
+
+		assert.Equal(t, "1.2.0", latestSuccessfulBuild.BuildVersion)
+		assert.True(t, behind)
+		assert.False(t, upToDate)
+		assert.False(t, ahead)
+		assert.True(t, neverReleased)
+	})
+
+	t.Run("UsesReleaseBranchesFromManifest", func(t *testing.T) {
+
+		builds := []*Build{
+			&Build{BuildVersion: "1.1.0", RepoBranch: "master", BuildStatus: StatusSucceeded, InsertedAt: time.Date(2018, 4, 17, 8, 1, 0, 0, time.UTC)},
+			&Build{BuildVersion: "1.2.0", RepoBranch: "release-1.2", BuildStatus: StatusSucceeded, InsertedAt: time.Date(2018, 4, 17, 8, 2, 0, 0, time.UTC)},
+		}
+
+		// act
+		latestSuccessfulBuild := GetLatestSuccessfulBuild(builds, manifest.StringOrStringArray{Values: []string{"release-.+"}})
+
+		assert.Equal(t, "1.2.0", latestSuccessfulBuild.BuildVersion)
+	})
+
+	t.Run("ReturnsFalseWithoutSuccessfulBuild", func(t *testing.T) {
+
+		// act
+		behind := (&ReleaseTarget{CurrentVersion: "1.1.0"}).IsBehind(GetLatestSuccessfulBuild(nil, manifest.StringOrStringArray{}))
+
+		assert.False(t, behind)
+	})
+}
+
+func getReleaseTargetsManifest() *manifest.ZiplineeManifest {
+	return &manifest.ZiplineeManifest{
+		Releases: []*manifest.ZiplineeRelease{
+			&manifest.ZiplineeRelease{Name: "development"},
+			&manifest.ZiplineeRelease{
+				Name: "production",
+				Actions: []*manifest.ZiplineeReleaseAction{
+					&manifest.ZiplineeReleaseAction{Name: "deploy-canary"},
+					&manifest.ZiplineeReleaseAction{Name: "deploy-stable", HideBadge: true},
+				},
+			},
+		},
+	}
+}
+
+func getReleaseTargetsRelease(name, action, version string, status Status, minute int) *Release {
+	insertedAt := time.Date(2018, 4, 17, 8, minute, 0, 0, time.UTC)
+	return &Release{
+		Name:           name,
+		Action:         action,
+		ReleaseVersion: version,
+		ReleaseStatus:  status,
+		InsertedAt:     &insertedAt,
+	}
+}
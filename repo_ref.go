@@ -0,0 +1,173 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RepoRef identifies a repository by its source, owner and name, like github.com/ziplineeci/ziplinee-ci-api; it marshals to and from that path.
+// Parsing and String keep the case as is, Equal ignores case and Normalize lowercases all parts for use as a key
+type RepoRef struct {
+	Source string
+	Owner  string
+	Name   string
+}
+
+// ParseRepoRef parses a source/owner/name path or a clone url like https://github.com/owner/name.git,
+// ssh://git@github.com/owner/name.git or git@github.com:owner/name.git; an owner can contain slashes for nested groups
+func ParseRepoRef(value string) (RepoRef, error) {
+
+	path := strings.TrimSpace(value)
+
+	switch {
+	case strings.Contains(path, "://"):
+		u, err := url.Parse(path)
+		if err != nil {
+			return RepoRef{}, fmt.Errorf("repository %q is not a valid clone url: %w", value, err)
+		}
+		path = u.Hostname() + u.Path
+	case strings.Contains(path, "@") && strings.Contains(path, ":"):
+		// scp-like syntax, user@host:owner/name.git
+		path = path[strings.Index(path, "@")+1:]
+		path = strings.Replace(path, ":", "/", 1)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return RepoRef{}, fmt.Errorf("repository %q needs to have a source, owner and name", value)
+	}
+
+	repoRef := RepoRef{
+		Source: parts[0],
+		Owner:  strings.Join(parts[1:len(parts)-1], "/"),
+		Name:   parts[len(parts)-1],
+	}
+
+	if err := repoRef.Validate(); err != nil {
+		return RepoRef{}, err
+	}
+
+	return repoRef, nil
+}
+
+// String returns the full path of the repository with source, owner and name
+func (repoRef RepoRef) String() string {
+	return fmt.Sprintf("%v/%v/%v", repoRef.Source, repoRef.Owner, repoRef.Name)
+}
+
+// IsZero returns true if none of source, owner and name are set
+func (repoRef RepoRef) IsZero() bool {
+	return repoRef.Source == "" && repoRef.Owner == "" && repoRef.Name == ""
+}
+
+// Validate returns an error if source, owner or name are missing or contain characters not allowed in a repository path
+func (repoRef RepoRef) Validate() error {
+
+	if repoRef.Source == "" || repoRef.Owner == "" || repoRef.Name == "" {
+		return errors.New("repository needs to have a source, owner and name")
+	}
+	if strings.Contains(repoRef.Source, "/") || strings.Contains(repoRef.Name, "/") {
+		return fmt.Errorf("repository %v has a source or name containing a slash", repoRef)
+	}
+	for _, segment := range append([]string{repoRef.Source, repoRef.Name}, strings.Split(repoRef.Owner, "/")...) {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("repository %v has an empty or relative path segment", repoRef)
+		}
+		if strings.ContainsAny(segment, " \t\r\n:@?#\\") {
+			return fmt.Errorf("repository %v contains invalid characters", repoRef)
+		}
+	}
+
+	return nil
+}
+
+// Normalize returns the repository with source, owner and name lowercased, since git hosting services treat paths
+// case-insensitive
+func (repoRef RepoRef) Normalize() RepoRef {
+	return RepoRef{
+		Source: strings.ToLower(repoRef.Source),
+		Owner:  strings.ToLower(repoRef.Owner),
+		Name:   strings.ToLower(repoRef.Name),
+	}
+}
+
+// Equal returns true if both refer to the same repository, which is the case if their normalized forms are the same
+func (repoRef RepoRef) Equal(other RepoRef) bool {
+	return repoRef.Normalize() == other.Normalize()
+}
+
+// MarshalText returns the full path of the repository; a zero RepoRef is marshalled as empty string
+func (repoRef RepoRef) MarshalText() ([]byte, error) {
+	if repoRef.IsZero() {
+		return []byte{}, nil
+	}
+
+	return []byte(repoRef.String()), nil
+}
+
+// UnmarshalText parses a full path or clone url of a repository; an empty string results in a zero RepoRef
+func (repoRef *RepoRef) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*repoRef = RepoRef{}
+		return nil
+	}
+
+	parsed, err := ParseRepoRef(string(text))
+	if err != nil {
+		return err
+	}
+	*repoRef = parsed
+
+	return nil
+}
+
+// GetRepoRef returns the repository of the build
+func (build *Build) GetRepoRef() RepoRef {
+	return RepoRef{Source: build.RepoSource, Owner: build.RepoOwner, Name: build.RepoName}
+}
+
+// GetRepoRef returns the repository of the pipeline
+func (pipeline *Pipeline) GetRepoRef() RepoRef {
+	return RepoRef{Source: pipeline.RepoSource, Owner: pipeline.RepoOwner, Name: pipeline.RepoName}
+}
+
+// GetRepoRef returns the repository of the release
+func (release *Release) GetRepoRef() RepoRef {
+	return RepoRef{Source: release.RepoSource, Owner: release.RepoOwner, Name: release.RepoName}
+}
+
+// GetRepoRef returns the repository of the bot
+func (bot *Bot) GetRepoRef() RepoRef {
+	return RepoRef{Source: bot.RepoSource, Owner: bot.RepoOwner, Name: bot.RepoName}
+}
+
+// GetRepoRef returns the repository of the git config
+func (gitConfig *GitConfig) GetRepoRef() RepoRef {
+	return RepoRef{Source: gitConfig.RepoSource, Owner: gitConfig.RepoOwner, Name: gitConfig.RepoName}
+}
+
+// GetRepoRef returns the repository of the build log
+func (buildLog *BuildLog) GetRepoRef() RepoRef {
+	return RepoRef{Source: buildLog.RepoSource, Owner: buildLog.RepoOwner, Name: buildLog.RepoName}
+}
+
+// GetRepoRef returns the repository of the release log
+func (releaseLog *ReleaseLog) GetRepoRef() RepoRef {
+	return RepoRef{Source: releaseLog.RepoSource, Owner: releaseLog.RepoOwner, Name: releaseLog.RepoName}
+}
+
+// GetRepoRef returns the repository of the bot log
+func (botLog *BotLog) GetRepoRef() RepoRef {
+	return RepoRef{Source: botLog.RepoSource, Owner: botLog.RepoOwner, Name: botLog.RepoName}
+}
+
+// SetRepoRef sets the source, owner and name of the git config
+func (gitConfig *GitConfig) SetRepoRef(repoRef RepoRef) {
+	gitConfig.RepoSource = repoRef.Source
+	gitConfig.RepoOwner = repoRef.Owner
+	gitConfig.RepoName = repoRef.Name
+}
@@ -0,0 +1,175 @@
+package contracts
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy defines when pipelines get archived and how many builds and build logs are kept; a value of 0
+// disables the rule. Pipelines with active releases are never archived and builds of actively released versions are
+// never purged
+type RetentionPolicy struct {
+	// ArchiveAfterDays archives pipelines without builds for this many days
+	ArchiveAfterDays int `json:"archiveAfterDays,omitempty" yaml:"archiveAfterDays,omitempty"`
+	// KeepBuildsPerBranch is the number of most recent finished builds kept per branch
+	KeepBuildsPerBranch int `json:"keepBuildsPerBranch,omitempty" yaml:"keepBuildsPerBranch,omitempty"`
+	// KeepLogsPerBranch is the number of most recent finished builds per branch for which the logs are kept
+	KeepLogsPerBranch int `json:"keepLogsPerBranch,omitempty" yaml:"keepLogsPerBranch,omitempty"`
+}
+
+// RetentionResult holds what to archive and purge according to a retention policy
+type RetentionResult struct {
+	PipelinesToArchive []*Pipeline `json:"pipelinesToArchive"`
+	BuildsToPurge      []*Build    `json:"buildsToPurge"`
+	// BuildLogsToPurge holds the builds that are kept but for which the logs can be purged
+	BuildLogsToPurge []*Build `json:"buildLogsToPurge"`
+}
+
+// Validate returns an error if any of the values in the policy is negative
+func (policy *RetentionPolicy) Validate() error {
+	if policy.ArchiveAfterDays < 0 {
+		return fmt.Errorf("archiveAfterDays %v can't be negative", policy.ArchiveAfterDays)
+	}
+	if policy.KeepBuildsPerBranch < 0 {
+		return fmt.Errorf("keepBuildsPerBranch %v can't be negative", policy.KeepBuildsPerBranch)
+	}
+	if policy.KeepLogsPerBranch < 0 {
+		return fmt.Errorf("keepLogsPerBranch %v can't be negative", policy.KeepLogsPerBranch)
+	}
+
+	return nil
+}
+
+// Evaluate returns the pipelines to archive and the builds and build logs to purge; builds and releases are matched
+// to pipelines by repository. Archived pipelines and unfinished builds are left alone
+func (policy *RetentionPolicy) Evaluate(pipelines []*Pipeline, builds []*Build, releases []*Release, clock Clock) (RetentionResult, error) {
+
+	result := RetentionResult{
+		PipelinesToArchive: []*Pipeline{},
+		BuildsToPurge:      []*Build{},
+		BuildLogsToPurge:   []*Build{},
+	}
+
+	if err := policy.Validate(); err != nil {
+		return result, err
+	}
+
+	buildsPerRepo := map[string][]*Build{}
+	for _, b := range builds {
+		if b == nil {
+			continue
+		}
+		key := getRetentionRepoKey(b.GetRepoRef())
+		buildsPerRepo[key] = append(buildsPerRepo[key], b)
+	}
+
+	releasesPerRepo := map[string][]*Release{}
+	for _, r := range releases {
+		if r == nil {
+			continue
+		}
+		key := getRetentionRepoKey(r.GetRepoRef())
+		releasesPerRepo[key] = append(releasesPerRepo[key], r)
+	}
+
+	now := clock.Now()
+	for _, p := range pipelines {
+		if p == nil || p.Archived {
+			continue
+		}
+		key := getRetentionRepoKey(p.GetRepoRef())
+		activeVersions := getActiveReleaseVersions(releasesPerRepo[key])
+
+		if policy.ArchiveAfterDays > 0 && len(activeVersions) == 0 {
+			lastBuildAt := p.InsertedAt
+			for _, b := range buildsPerRepo[key] {
+				if b.InsertedAt.After(lastBuildAt) {
+					lastBuildAt = b.InsertedAt
+				}
+			}
+			if !lastBuildAt.IsZero() && now.Sub(lastBuildAt) >= time.Duration(policy.ArchiveAfterDays)*24*time.Hour {
+				result.PipelinesToArchive = append(result.PipelinesToArchive, p)
+			}
+		}
+
+		buildsToPurge, buildLogsToPurge := policy.getBuildsToPurge(buildsPerRepo[key], activeVersions)
+		result.BuildsToPurge = append(result.BuildsToPurge, buildsToPurge...)
+		result.BuildLogsToPurge = append(result.BuildLogsToPurge, buildLogsToPurge...)
+	}
+
+	return result, nil
+}
+
+// getBuildsToPurge returns the finished builds of a single pipeline beyond the number of builds and logs to keep per branch
+func (policy *RetentionPolicy) getBuildsToPurge(builds []*Build, activeVersions map[string]bool) (buildsToPurge []*Build, buildLogsToPurge []*Build) {
+
+	buildsToPurge = []*Build{}
+	buildLogsToPurge = []*Build{}
+
+	buildsPerBranch := map[string][]*Build{}
+	branches := []string{}
+	for _, b := range builds {
+		if !b.BuildStatus.IsFinished() {
+			continue
+		}
+		if _, ok := buildsPerBranch[b.RepoBranch]; !ok {
+			branches = append(branches, b.RepoBranch)
+		}
+		buildsPerBranch[b.RepoBranch] = append(buildsPerBranch[b.RepoBranch], b)
+	}
+	sort.Strings(branches)
+
+	for _, branch := range branches {
+		branchBuilds := buildsPerBranch[branch]
+		// latest first, so the builds to keep come first
+		sort.SliceStable(branchBuilds, func(i, j int) bool {
+			return branchBuilds[i].InsertedAt.After(branchBuilds[j].InsertedAt)
+		})
+
+		for i, b := range branchBuilds {
+			if activeVersions[b.BuildVersion] {
+				continue
+			}
+			switch {
+			case policy.KeepBuildsPerBranch > 0 && i >= policy.KeepBuildsPerBranch:
+				buildsToPurge = append(buildsToPurge, b)
+			case policy.KeepLogsPerBranch > 0 && i >= policy.KeepLogsPerBranch:
+				buildLogsToPurge = append(buildLogsToPurge, b)
+			}
+		}
+	}
+
+	return
+}
+
+// getActiveReleaseVersions returns the versions of the latest release per target and action that is either still in
+// progress or succeeded, and as such is what's currently deployed
+func getActiveReleaseVersions(releases []*Release) map[string]bool {
+
+	sortedReleases := append([]*Release{}, releases...)
+	sort.SliceStable(sortedReleases, func(i, j int) bool {
+		return getTimeOrZero(sortedReleases[i].InsertedAt).After(getTimeOrZero(sortedReleases[j].InsertedAt))
+	})
+
+	activeVersions := map[string]bool{}
+	seen := map[string]bool{}
+	for _, r := range sortedReleases {
+		key := r.Name + "/" + r.Action
+		if seen[key] {
+			continue
+		}
+		// a failed or canceled release leaves whatever was released before in place
+		if r.ReleaseStatus == StatusFailed || r.ReleaseStatus == StatusCanceled {
+			continue
+		}
+		seen[key] = true
+		activeVersions[r.ReleaseVersion] = true
+	}
+
+	return activeVersions
+}
+
+func getRetentionRepoKey(repoRef RepoRef) string {
+	return repoRef.Normalize().String()
+}
@@ -0,0 +1,135 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetentionPolicyValidate(t *testing.T) {
+	t.Run("ReturnsErrorForNegativeValues", func(t *testing.T) {
+
+		policy := RetentionPolicy{KeepLogsPerBranch: -1}
+
+		// act
+		err := policy.Validate()
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRetentionPolicyEvaluate(t *testing.T) {
+	t.Run("ArchivesPipelinesWithoutRecentBuildsOrActiveReleases", func(t *testing.T) {
+
+		policy := RetentionPolicy{ArchiveAfterDays: 30}
+		stale := getRetentionPipeline("stale", 40)
+		staleWithRelease := getRetentionPipeline("stale-with-release", 40)
+		staleWithRecentBuild := getRetentionPipeline("stale-with-recent-build", 40)
+		recent := getRetentionPipeline("recent", 5)
+		archived := getRetentionPipeline("archived", 90)
+		archived.Archived = true
+
+		builds := []*Build{getRetentionBuild("stale-with-recent-build", "main", "1.0.1", StatusSucceeded, 10)}
+		releases := []*Release{
+			getRetentionRelease("stale-with-release", "production", "1.0.0", StatusSucceeded, 40),
+			getRetentionRelease("stale", "production", "1.0.0", StatusFailed, 40),
+		}
+
+		// act
+		result, err := policy.Evaluate([]*Pipeline{stale, staleWithRelease, staleWithRecentBuild, recent, archived}, builds, releases, getRetentionClock())
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*Pipeline{stale}, result.PipelinesToArchive)
+		assert.Equal(t, 0, len(result.BuildsToPurge))
+	})
+
+	t.Run("KeepsLastBuildsAndLogsPerBranch", func(t *testing.T) {
+
+		policy := RetentionPolicy{KeepBuildsPerBranch: 3, KeepLogsPerBranch: 1}
+		pipeline := getRetentionPipeline("api", 1)
+		builds := []*Build{
+			getRetentionBuild("api", "main", "1.0.0", StatusSucceeded, 10),
+			getRetentionBuild("api", "main", "1.0.1", StatusSucceeded, 9),
+			getRetentionBuild("api", "main", "1.0.2", StatusFailed, 8),
+			getRetentionBuild("api", "main", "1.0.3", StatusSucceeded, 7),
+			getRetentionBuild("api", "main", "1.0.4", StatusRunning, 1),
+			getRetentionBuild("api", "feature", "1.0.5-feature", StatusSucceeded, 6),
+			getRetentionBuild("api", "feature", "1.0.6-feature", StatusSucceeded, 5),
+			getRetentionBuild("other", "main", "2.0.0", StatusSucceeded, 50),
+		}
+		releases := []*Release{
+			getRetentionRelease("api", "production", "1.0.0", StatusSucceeded, 9),
+		}
+
+		// act
+		result, err := policy.Evaluate([]*Pipeline{pipeline}, builds, releases, getRetentionClock())
+
+		assert.Nil(t, err)
+		// 1.0.0 is beyond the builds to keep but actively released
+		assert.Equal(t, []*Build{}, result.BuildsToPurge)
+		assert.Equal(t, []*Build{builds[5], builds[2], builds[1]}, result.BuildLogsToPurge)
+	})
+
+	t.Run("PurgesBuildsBeyondBuildsToKeep", func(t *testing.T) {
+
+		policy := RetentionPolicy{KeepBuildsPerBranch: 1}
+		pipeline := getRetentionPipeline("api", 1)
+		builds := []*Build{
+			getRetentionBuild("api", "main", "1.0.0", StatusSucceeded, 10),
+			getRetentionBuild("api", "main", "1.0.1", StatusCanceled, 9),
+			getRetentionBuild("api", "main", "1.0.2", StatusSucceeded, 8),
+		}
+
+		// act
+		result, err := policy.Evaluate([]*Pipeline{pipeline}, builds, nil, getRetentionClock())
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*Build{builds[1], builds[0]}, result.BuildsToPurge)
+		assert.Equal(t, []*Build{}, result.BuildLogsToPurge)
+	})
+}
+
+func getRetentionClock() Clock {
+	return ClockFunc(func() time.Time {
+		return time.Date(2018, 4, 17, 8, 0, 0, 0, time.UTC)
+	})
+}
+
+func getRetentionDaysAgo(days int) time.Time {
+	return getRetentionClock().Now().Add(-time.Duration(days) * 24 * time.Hour)
+}
+
+func getRetentionPipeline(name string, lastBuildDaysAgo int) *Pipeline {
+	return &Pipeline{
+		RepoSource: "github.com",
+		RepoOwner:  "ziplineeci",
+		RepoName:   name,
+		InsertedAt: getRetentionDaysAgo(lastBuildDaysAgo),
+	}
+}
+
+func getRetentionBuild(name, branch, version string, status Status, daysAgo int) *Build {
+	return &Build{
+		RepoSource:   "github.com",
+		RepoOwner:    "ziplineeci",
+		RepoName:     name,
+		RepoBranch:   branch,
+		BuildVersion: version,
+		BuildStatus:  status,
+		InsertedAt:   getRetentionDaysAgo(daysAgo),
+	}
+}
+
+func getRetentionRelease(name, target, version string, status Status, daysAgo int) *Release {
+	insertedAt := getRetentionDaysAgo(daysAgo)
+	return &Release{
+		Name:           target,
+		RepoSource:     "github.com",
+		RepoOwner:      "ziplineeci",
+		RepoName:       name,
+		ReleaseVersion: version,
+		ReleaseStatus:  status,
+		InsertedAt:     &insertedAt,
+	}
+}
@@ -0,0 +1,397 @@
+package contracts
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	semanticVersionRegex        = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)(?:\.(0|[1-9]\d*))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`)
+	versionConstraintRegex      = regexp.MustCompile(`^(>=|<=|!=|==|=|>|<)?\s*(\S+)$`)
+	versionConstraintSpaceRegex = regexp.MustCompile(`(>=|<=|!=|==|=|>|<)\s+`)
+)
+
+// SemanticVersion is a parsed build or release version, like 1.4.0 or 1.4.1-feature-branch; build metadata after a + is ignored
+type SemanticVersion struct {
+	Major int    `json:"major"`
+	Minor int    `json:"minor"`
+	Patch int    `json:"patch"`
+	Label string `json:"label,omitempty"`
+}
+
+// ParseSemanticVersion parses a version with an optional v prefix, optional patch number and optional label
+func ParseSemanticVersion(version string) (SemanticVersion, error) {
+	matches := semanticVersionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if matches == nil {
+		return SemanticVersion{}, fmt.Errorf("version %q is not a semantic version", version)
+	}
+
+	semanticVersion := SemanticVersion{Label: matches[4]}
+	semanticVersion.Major, _ = strconv.Atoi(matches[1])
+	semanticVersion.Minor, _ = strconv.Atoi(matches[2])
+	if matches[3] != "" {
+		semanticVersion.Patch, _ = strconv.Atoi(matches[3])
+	}
+
+	return semanticVersion, nil
+}
+
+// String returns the version as major.minor.patch followed by the label, if any
+func (version SemanticVersion) String() string {
+	if version.Label != "" {
+		return fmt.Sprintf("%v.%v.%v-%v", version.Major, version.Minor, version.Patch, version.Label)
+	}
+
+	return fmt.Sprintf("%v.%v.%v", version.Major, version.Minor, version.Patch)
+}
+
+// Compare returns -1, 0 or 1 if the version is lower than, equal to or higher than the other version; a version with
+// a label is lower than the same version without label and labels are compared per dot separated identifier
+func (version SemanticVersion) Compare(other SemanticVersion) int {
+	for _, c := range [][2]int{{version.Major, other.Major}, {version.Minor, other.Minor}, {version.Patch, other.Patch}} {
+		if c[0] != c[1] {
+			return compareInts(c[0], c[1])
+		}
+	}
+
+	switch {
+	case version.Label == other.Label:
+		return 0
+	case version.Label == "":
+		return 1
+	case other.Label == "":
+		return -1
+	}
+
+	identifiers := strings.Split(version.Label, ".")
+	otherIdentifiers := strings.Split(other.Label, ".")
+	for i := 0; i < len(identifiers) && i < len(otherIdentifiers); i++ {
+		if c := compareVersionIdentifiers(identifiers[i], otherIdentifiers[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(identifiers), len(otherIdentifiers))
+}
+
+// VersionConstraint is a single comparison in a version range, like >=1.4.0
+type VersionConstraint struct {
+	Operator string          `json:"operator"`
+	Version  SemanticVersion `json:"version"`
+}
+
+// VersionRange holds alternatives separated by || of which at least one needs to match, each being a space separated
+// list of constraints that all need to match, like >=1.4.0 <2.0.0 || >=3.0.0
+type VersionRange struct {
+	Alternatives [][]VersionConstraint `json:"alternatives"`
+}
+
+// ParseVersionRange parses a version range, like >=1.4.0 <2.0.0; a version without operator needs to match exactly
+func ParseVersionRange(versionRange string) (VersionRange, error) {
+
+	parsedRange := VersionRange{
+		Alternatives: [][]VersionConstraint{},
+	}
+
+	for _, alternative := range strings.Split(versionRange, "||") {
+		// allow a space between operator and version, like >= 1.4.0
+		alternative = versionConstraintSpaceRegex.ReplaceAllString(strings.TrimSpace(alternative), "$1")
+
+		constraints := []VersionConstraint{}
+		for _, c := range strings.Fields(alternative) {
+			matches := versionConstraintRegex.FindStringSubmatch(c)
+			if matches == nil {
+				return parsedRange, fmt.Errorf("version constraint %q is invalid", c)
+			}
+			version, err := ParseSemanticVersion(matches[2])
+			if err != nil {
+				return parsedRange, fmt.Errorf("version constraint %q is invalid: %w", c, err)
+			}
+			operator := matches[1]
+			if operator == "" || operator == "==" {
+				operator = "="
+			}
+			constraints = append(constraints, VersionConstraint{Operator: operator, Version: version})
+		}
+		if len(constraints) == 0 {
+			return parsedRange, fmt.Errorf("version range %q has an empty alternative", versionRange)
+		}
+
+		parsedRange.Alternatives = append(parsedRange.Alternatives, constraints)
+	}
+
+	return parsedRange, nil
+}
+
+// Contains returns true if the version meets all constraints of at least one of the alternatives
+func (versionRange VersionRange) Contains(version SemanticVersion) bool {
+	for _, alternative := range versionRange.Alternatives {
+		matches := true
+		for _, c := range alternative {
+			if !c.Matches(version) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches returns true if the version meets the constraint
+func (constraint VersionConstraint) Matches(version SemanticVersion) bool {
+	c := version.Compare(constraint.Version)
+	switch constraint.Operator {
+	case ">=":
+		return c >= 0
+	case ">":
+		return c > 0
+	case "<=":
+		return c <= 0
+	case "<":
+		return c < 0
+	case "!=":
+		return c != 0
+	}
+
+	return c == 0
+}
+
+// GetSemanticVersion returns the parsed build version
+func (build *Build) GetSemanticVersion() (SemanticVersion, error) {
+	return ParseSemanticVersion(build.BuildVersion)
+}
+
+// GetSemanticVersion returns the parsed release version
+func (release *Release) GetSemanticVersion() (SemanticVersion, error) {
+	return ParseSemanticVersion(release.ReleaseVersion)
+}
+
+// CompareBuildVersions returns -1, 0 or 1 if the version of build a is lower than, equal to or higher than the version
+// of build b; versions that can't be parsed are lower than any valid version and ties are broken by insert time
+func CompareBuildVersions(a, b *Build) int {
+	if c := compareVersionStrings(a.BuildVersion, b.BuildVersion); c != 0 {
+		return c
+	}
+
+	return compareTimes(a.InsertedAt, b.InsertedAt)
+}
+
+// CompareReleaseVersions returns -1, 0 or 1 if the version of release a is lower than, equal to or higher than the
+// version of release b; versions that can't be parsed are lower than any valid version and ties are broken by insert time
+func CompareReleaseVersions(a, b *Release) int {
+	if c := compareVersionStrings(a.ReleaseVersion, b.ReleaseVersion); c != 0 {
+		return c
+	}
+
+	return compareTimes(getTimeOrZero(a.InsertedAt), getTimeOrZero(b.InsertedAt))
+}
+
+// SortBuildsByVersion sorts the builds by version, highest version first
+func SortBuildsByVersion(builds []*Build) {
+	sort.SliceStable(builds, func(i, j int) bool {
+		return CompareBuildVersions(builds[i], builds[j]) > 0
+	})
+}
+
+// SortReleasesByVersion sorts the releases by version, highest version first
+func SortReleasesByVersion(releases []*Release) {
+	sort.SliceStable(releases, func(i, j int) bool {
+		return CompareReleaseVersions(releases[i], releases[j]) > 0
+	})
+}
+
+// BuildQuery filters builds; empty fields don't filter
+type BuildQuery struct {
+	Statuses []Status `json:"statuses,omitempty"`
+	Branches []string `json:"branches,omitempty"`
+	// VersionRange holds a range like >=1.4.0 <2.0.0; builds with versions that can't be parsed never match
+	VersionRange string `json:"versionRange,omitempty"`
+}
+
+// ReleaseQuery filters releases; empty fields don't filter
+type ReleaseQuery struct {
+	Statuses []Status `json:"statuses,omitempty"`
+	Names    []string `json:"names,omitempty"`
+	Actions  []string `json:"actions,omitempty"`
+	// VersionRange holds a range like >=1.4.0 <2.0.0; releases with versions that can't be parsed never match
+	VersionRange string `json:"versionRange,omitempty"`
+}
+
+// FilterBuilds returns the builds matching the query, highest version first
+func FilterBuilds(builds []*Build, query BuildQuery) ([]*Build, error) {
+
+	versionRange, err := parseOptionalVersionRange(query.VersionRange)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredBuilds := []*Build{}
+	for _, b := range builds {
+		if b == nil || !containsStatus(query.Statuses, b.BuildStatus) || !containsString(query.Branches, b.RepoBranch) {
+			continue
+		}
+		if versionRange != nil && !versionRangeContains(versionRange, b.BuildVersion) {
+			continue
+		}
+		filteredBuilds = append(filteredBuilds, b)
+	}
+	SortBuildsByVersion(filteredBuilds)
+
+	return filteredBuilds, nil
+}
+
+// FilterReleases returns the releases matching the query, highest version first
+func FilterReleases(releases []*Release, query ReleaseQuery) ([]*Release, error) {
+
+	versionRange, err := parseOptionalVersionRange(query.VersionRange)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredReleases := []*Release{}
+	for _, r := range releases {
+		if r == nil || !containsStatus(query.Statuses, r.ReleaseStatus) || !containsString(query.Names, r.Name) || !containsString(query.Actions, r.Action) {
+			continue
+		}
+		if versionRange != nil && !versionRangeContains(versionRange, r.ReleaseVersion) {
+			continue
+		}
+		filteredReleases = append(filteredReleases, r)
+	}
+	SortReleasesByVersion(filteredReleases)
+
+	return filteredReleases, nil
+}
+
+// GetLatestBuildByVersion returns the build with the highest version matching the query, like the latest succeeded
+// build on master, or nil if none match
+func GetLatestBuildByVersion(builds []*Build, query BuildQuery) (*Build, error) {
+	filteredBuilds, err := FilterBuilds(builds, query)
+	if err != nil || len(filteredBuilds) == 0 {
+		return nil, err
+	}
+
+	return filteredBuilds[0], nil
+}
+
+// GetLatestReleaseByVersion returns the release with the highest version matching the query or nil if none match
+func GetLatestReleaseByVersion(releases []*Release, query ReleaseQuery) (*Release, error) {
+	filteredReleases, err := FilterReleases(releases, query)
+	if err != nil || len(filteredReleases) == 0 {
+		return nil, err
+	}
+
+	return filteredReleases[0], nil
+}
+
+func parseOptionalVersionRange(versionRange string) (*VersionRange, error) {
+	if strings.TrimSpace(versionRange) == "" {
+		return nil, nil
+	}
+
+	parsedRange, err := ParseVersionRange(versionRange)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsedRange, nil
+}
+
+func versionRangeContains(versionRange *VersionRange, version string) bool {
+	semanticVersion, err := ParseSemanticVersion(version)
+	if err != nil {
+		return false
+	}
+
+	return versionRange.Contains(semanticVersion)
+}
+
+// compareVersionStrings compares two versions, with versions that can't be parsed sorting lower and among each other by string
+func compareVersionStrings(a, b string) int {
+	versionA, errA := ParseSemanticVersion(a)
+	versionB, errB := ParseSemanticVersion(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return versionA.Compare(versionB)
+	case errA == nil:
+		return 1
+	case errB == nil:
+		return -1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// compareVersionIdentifiers compares label identifiers: numeric identifiers numerically and lower than alphanumeric ones
+func compareVersionIdentifiers(a, b string) int {
+	numberA, errA := strconv.Atoi(a)
+	numberB, errB := strconv.Atoi(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(numberA, numberB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+
+	return 0
+}
+
+func containsStatus(statuses []Status, status Status) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,175 @@
+package contracts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemanticVersion(t *testing.T) {
+	t.Run("ParsesVersionWithLabelAndMetadata", func(t *testing.T) {
+
+		// act
+		version, err := ParseSemanticVersion("v1.4.12-feature-branch.3+build.7")
+
+		assert.Nil(t, err)
+		assert.Equal(t, SemanticVersion{Major: 1, Minor: 4, Patch: 12, Label: "feature-branch.3"}, version)
+		assert.Equal(t, "1.4.12-feature-branch.3", version.String())
+	})
+
+	t.Run("DefaultsPatchToZero", func(t *testing.T) {
+
+		// act
+		version, err := ParseSemanticVersion("2.1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2.1.0", version.String())
+	})
+
+	t.Run("ReturnsErrorForInvalidVersion", func(t *testing.T) {
+
+		for _, v := range []string{"", "1", "1.2.3.4", "01.2.3", "1.2.3-", "latest"} {
+			// act
+			_, err := ParseSemanticVersion(v)
+
+			assert.NotNil(t, err, v)
+		}
+	})
+}
+
+func TestSemanticVersionCompare(t *testing.T) {
+	t.Run("FollowsSemanticVersioningPrecedence", func(t *testing.T) {
+
+		ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "1.10.0", "2.0.0"}
+
+		for i := 0; i < len(ordered)-1; i++ {
+			lower, _ := ParseSemanticVersion(ordered[i])
+			higher, _ := ParseSemanticVersion(ordered[i+1])
+
+			// act
+			assert.Equal(t, -1, lower.Compare(higher), ordered[i])
+			assert.Equal(t, 1, higher.Compare(lower), ordered[i+1])
+			assert.Equal(t, 0, lower.Compare(lower), ordered[i])
+		}
+	})
+}
+
+func TestParseVersionRange(t *testing.T) {
+	t.Run("MatchesAllConstraintsOfAnAlternative", func(t *testing.T) {
+
+		versionRange, err := ParseVersionRange(">=1.4.0 <2.0.0 || >= 3.0.0")
+		assert.Nil(t, err)
+
+		for version, expected := range map[string]bool{
+			"1.3.9":   false,
+			"1.4.0":   true,
+			"1.9.12":  true,
+			"2.0.0":   false,
+			"2.5.0":   false,
+			"3.0.0":   true,
+			"1.4.0-a": false,
+		} {
+			v, _ := ParseSemanticVersion(version)
+
+			// act
+			contains := versionRange.Contains(v)
+
+			assert.Equal(t, expected, contains, version)
+		}
+	})
+
+	t.Run("ReturnsErrorForInvalidRange", func(t *testing.T) {
+
+		for _, r := range []string{"", ">=1.4.0 ||", "~1.4.0", ">=latest"} {
+			// act
+			_, err := ParseVersionRange(r)
+
+			assert.NotNil(t, err, r)
+		}
+	})
+}
+
+func TestFilterBuilds(t *testing.T) {
+	t.Run("FiltersByStatusBranchAndVersionRangeOrderedByVersion", func(t *testing.T) {
+
+		builds := getVersionBuilds()
+
+		// act
+		filteredBuilds, err := FilterBuilds(builds, BuildQuery{Statuses: []Status{StatusSucceeded}, Branches: []string{"master"}, VersionRange: ">=1.4.0"})
+
+		assert.Nil(t, err)
+		versions := []string{}
+		for _, b := range filteredBuilds {
+			versions = append(versions, b.BuildVersion)
+		}
+		assert.Equal(t, []string{"1.10.0", "1.9.0", "1.4.0"}, versions)
+	})
+
+	t.Run("ReturnsLatestSuccessfulVersionOnBranch", func(t *testing.T) {
+
+		builds := getVersionBuilds()
+
+		// act
+		build, err := GetLatestBuildByVersion(builds, BuildQuery{Statuses: []Status{StatusSucceeded}, Branches: []string{"master"}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "1.10.0", build.BuildVersion)
+	})
+
+	t.Run("SortsUnparseableVersionsLast", func(t *testing.T) {
+
+		builds := getVersionBuilds()
+
+		// act
+		SortBuildsByVersion(builds)
+
+		assert.Equal(t, "2.0.0-beta", builds[0].BuildVersion)
+		assert.Equal(t, "latest", builds[len(builds)-1].BuildVersion)
+	})
+
+	t.Run("ReturnsErrorForInvalidVersionRange", func(t *testing.T) {
+
+		// act
+		_, err := FilterBuilds(getVersionBuilds(), BuildQuery{VersionRange: ">=x"})
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFilterReleases(t *testing.T) {
+	t.Run("ReturnsReleasesNewerThanVersion", func(t *testing.T) {
+
+		releases := []*Release{
+			getReleaseTargetsRelease("production", "", "1.3.0", StatusSucceeded, 1),
+			getReleaseTargetsRelease("production", "", "1.10.0", StatusSucceeded, 2),
+			getReleaseTargetsRelease("production", "", "1.5.0", StatusFailed, 3),
+			getReleaseTargetsRelease("staging", "", "1.6.0", StatusSucceeded, 4),
+		}
+
+		// act
+		filteredReleases, err := FilterReleases(releases, ReleaseQuery{Names: []string{"production"}, VersionRange: ">1.4.0"})
+		latest, latestErr := GetLatestReleaseByVersion(releases, ReleaseQuery{Statuses: []Status{StatusSucceeded}, VersionRange: "<1.10.0"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*Release{releases[1], releases[2]}, filteredReleases)
+		assert.Nil(t, latestErr)
+		assert.Equal(t, releases[3], latest)
+	})
+}
+
+func getVersionBuilds() []*Build {
+	getBuild := func(version, branch string, status Status, minute int) *Build {
+		return &Build{BuildVersion: version, RepoBranch: branch, BuildStatus: status, InsertedAt: time.Date(2018, 4, 17, 8, minute, 0, 0, time.UTC)}
+	}
+
+	return []*Build{
+		getBuild("1.3.0", "master", StatusSucceeded, 1),
+		getBuild("1.4.0", "master", StatusSucceeded, 2),
+		getBuild("latest", "master", StatusSucceeded, 3),
+		getBuild("1.10.0", "master", StatusSucceeded, 4),
+		getBuild("1.9.0", "master", StatusSucceeded, 5),
+		getBuild("1.11.0", "master", StatusFailed, 6),
+		getBuild("2.0.0-beta", "feature", StatusSucceeded, 7),
+	}
+}